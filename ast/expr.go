@@ -12,10 +12,18 @@ type VisitorExpr[R any] interface {
 	VisitCall(expr *Call) (R, error)
 	VisitGet(expr *Get) (R, error)
 	VisitGrouping(expr *Grouping) (R, error)
+	VisitIndexGet(expr *IndexGet) (R, error)
+	VisitIndexSet(expr *IndexSet) (R, error)
+	VisitInterpolation(expr *Interpolation) (R, error)
+	VisitLambda(expr *Lambda) (R, error)
+	VisitList(expr *List) (R, error)
 	VisitLiteral(expr *Literal) (R, error)
 	VisitLogical(expr *Logical) (R, error)
+	VisitMap(expr *Map) (R, error)
 	VisitSet(expr *Set) (R, error)
 	VisitSuper(expr *Super) (R, error)
+	VisitSuperSet(expr *SuperSet) (R, error)
+	VisitTernary(expr *Ternary) (R, error)
 	VisitThis(expr *This) (R, error)
 	VisitUnary(expr *Unary) (R, error)
 	VisitVariable(expr *Variable) (R, error)
@@ -67,6 +75,54 @@ func (n *Grouping) Accept(v VisitorExpr[any]) (any, error) {
 	return v.VisitGrouping(n)
 }
 
+type IndexGet struct {
+	Object  Expr
+	Bracket *lexer.Token
+	Index   Expr
+}
+
+func (n *IndexGet) Accept(v VisitorExpr[any]) (any, error) {
+	return v.VisitIndexGet(n)
+}
+
+type IndexSet struct {
+	Object  Expr
+	Bracket *lexer.Token
+	Index   Expr
+	Value   Expr
+}
+
+func (n *IndexSet) Accept(v VisitorExpr[any]) (any, error) {
+	return v.VisitIndexSet(n)
+}
+
+type Interpolation struct {
+	Start *lexer.Token
+	Parts []Expr
+}
+
+func (n *Interpolation) Accept(v VisitorExpr[any]) (any, error) {
+	return v.VisitInterpolation(n)
+}
+
+type Lambda struct {
+	Keyword  *lexer.Token
+	Function *Function
+}
+
+func (n *Lambda) Accept(v VisitorExpr[any]) (any, error) {
+	return v.VisitLambda(n)
+}
+
+type List struct {
+	Bracket  *lexer.Token
+	Elements []Expr
+}
+
+func (n *List) Accept(v VisitorExpr[any]) (any, error) {
+	return v.VisitList(n)
+}
+
 type Literal struct {
 	Value any
 }
@@ -85,6 +141,16 @@ func (n *Logical) Accept(v VisitorExpr[any]) (any, error) {
 	return v.VisitLogical(n)
 }
 
+type Map struct {
+	Brace  *lexer.Token
+	Keys   []Expr
+	Values []Expr
+}
+
+func (n *Map) Accept(v VisitorExpr[any]) (any, error) {
+	return v.VisitMap(n)
+}
+
 type Set struct {
 	Object Expr
 	Name   *lexer.Token
@@ -104,6 +170,26 @@ func (n *Super) Accept(v VisitorExpr[any]) (any, error) {
 	return v.VisitSuper(n)
 }
 
+type SuperSet struct {
+	Keyword *lexer.Token
+	Name    *lexer.Token
+	Value   Expr
+}
+
+func (n *SuperSet) Accept(v VisitorExpr[any]) (any, error) {
+	return v.VisitSuperSet(n)
+}
+
+type Ternary struct {
+	Condition  Expr
+	ThenBranch Expr
+	ElseBranch Expr
+}
+
+func (n *Ternary) Accept(v VisitorExpr[any]) (any, error) {
+	return v.VisitTernary(n)
+}
+
 type This struct {
 	Keyword *lexer.Token
 }
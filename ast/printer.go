@@ -45,6 +45,30 @@ func (p Printer) VisitGrouping(expr *Grouping) (any, error) {
 	return p.parenthesize("group", expr.Expression)
 }
 
+func (p Printer) VisitIndexGet(expr *IndexGet) (any, error) {
+	return p.parenthesize("index", expr.Object, expr.Index)
+}
+
+func (p Printer) VisitIndexSet(expr *IndexSet) (any, error) {
+	return p.parenthesize("index=", expr.Object, expr.Index, expr.Value)
+}
+
+func (p Printer) VisitInterpolation(expr *Interpolation) (any, error) {
+	return p.parenthesize("interpolate", expr.Parts...)
+}
+
+func (p Printer) VisitLambda(expr *Lambda) (any, error) {
+	params := make([]string, len(expr.Function.Params))
+	for i, param := range expr.Function.Params {
+		params[i] = param.Lexeme
+	}
+	return "(fun (" + strings.Join(params, " ") + "))", nil
+}
+
+func (p Printer) VisitList(expr *List) (any, error) {
+	return p.parenthesize("list", expr.Elements...)
+}
+
 func (p Printer) VisitLiteral(expr *Literal) (any, error) {
 	if expr.Value == nil {
 		return "nil", nil
@@ -56,6 +80,14 @@ func (p Printer) VisitLogical(expr *Logical) (any, error) {
 	return p.parenthesize(expr.Operator.Lexeme, expr.Left, expr.Right)
 }
 
+func (p Printer) VisitMap(expr *Map) (any, error) {
+	entries := []Expr{}
+	for i := range expr.Keys {
+		entries = append(entries, expr.Keys[i], expr.Values[i])
+	}
+	return p.parenthesize("map", entries...)
+}
+
 func (p Printer) VisitSet(expr *Set) (any, error) {
 	// TODO: improve print representation of this tree node
 	return p.parenthesize("set "+expr.Name.Lexeme, expr.Object, expr.Value)
@@ -66,6 +98,14 @@ func (p Printer) VisitSuper(expr *Super) (any, error) {
 	return p.parenthesize("super " + expr.Method.Lexeme)
 }
 
+func (p Printer) VisitSuperSet(expr *SuperSet) (any, error) {
+	return p.parenthesize("super set "+expr.Name.Lexeme, expr.Value)
+}
+
+func (p Printer) VisitTernary(expr *Ternary) (any, error) {
+	return p.parenthesize("?:", expr.Condition, expr.ThenBranch, expr.ElseBranch)
+}
+
 func (p Printer) VisitThis(expr *This) (any, error) {
 	return "this", nil
 }
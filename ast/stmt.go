@@ -8,12 +8,19 @@ type Stmt interface {
 
 type VisitorStmt[R any] interface {
 	VisitBlock(expr *Block) (R, error)
+	VisitBreak(expr *Break) (R, error)
 	VisitClass(expr *Class) (R, error)
+	VisitContinue(expr *Continue) (R, error)
+	VisitExport(expr *Export) (R, error)
 	VisitExpression(expr *Expression) (R, error)
 	VisitFunction(expr *Function) (R, error)
 	VisitIf(expr *If) (R, error)
+	VisitImport(expr *Import) (R, error)
 	VisitPrint(expr *Print) (R, error)
 	VisitReturn(expr *Return) (R, error)
+	VisitThrow(expr *Throw) (R, error)
+	VisitTrait(expr *Trait) (R, error)
+	VisitTry(expr *Try) (R, error)
 	VisitVar(expr *Var) (R, error)
 	VisitWhile(expr *While) (R, error)
 }
@@ -26,16 +33,45 @@ func (n *Block) Accept(v VisitorStmt[any]) (any, error) {
 	return v.VisitBlock(n)
 }
 
+type Break struct {
+	Keyword *lexer.Token
+}
+
+func (n *Break) Accept(v VisitorStmt[any]) (any, error) {
+	return v.VisitBreak(n)
+}
+
 type Class struct {
-	Name       *lexer.Token
-	Superclass *Variable
-	Methods    []*Function
+	Name         *lexer.Token
+	Superclass   *Variable
+	Methods      []*Function
+	ClassMethods []*Function
+	Getters      []*Function
+	Setters      []*Function
+	Traits       []*Variable
 }
 
 func (n *Class) Accept(v VisitorStmt[any]) (any, error) {
 	return v.VisitClass(n)
 }
 
+type Continue struct {
+	Keyword *lexer.Token
+}
+
+func (n *Continue) Accept(v VisitorStmt[any]) (any, error) {
+	return v.VisitContinue(n)
+}
+
+type Export struct {
+	Keyword     *lexer.Token
+	Declaration Stmt
+}
+
+func (n *Export) Accept(v VisitorStmt[any]) (any, error) {
+	return v.VisitExport(n)
+}
+
 type Expression struct {
 	Expression Expr
 }
@@ -64,7 +100,19 @@ func (n *If) Accept(v VisitorStmt[any]) (any, error) {
 	return v.VisitIf(n)
 }
 
+type Import struct {
+	Keyword *lexer.Token
+	Path    *lexer.Token
+	Alias   *lexer.Token
+	Names   []*lexer.Token
+}
+
+func (n *Import) Accept(v VisitorStmt[any]) (any, error) {
+	return v.VisitImport(n)
+}
+
 type Print struct {
+	Keyword    *lexer.Token
 	Expression Expr
 }
 
@@ -81,6 +129,35 @@ func (n *Return) Accept(v VisitorStmt[any]) (any, error) {
 	return v.VisitReturn(n)
 }
 
+type Throw struct {
+	Keyword *lexer.Token
+	Value   Expr
+}
+
+func (n *Throw) Accept(v VisitorStmt[any]) (any, error) {
+	return v.VisitThrow(n)
+}
+
+type Trait struct {
+	Name    *lexer.Token
+	Methods []*Function
+}
+
+func (n *Trait) Accept(v VisitorStmt[any]) (any, error) {
+	return v.VisitTrait(n)
+}
+
+type Try struct {
+	Body        []Stmt
+	CatchName   *lexer.Token
+	CatchBody   []Stmt
+	FinallyBody []Stmt
+}
+
+func (n *Try) Accept(v VisitorStmt[any]) (any, error) {
+	return v.VisitTry(n)
+}
+
 type Var struct {
 	Name        *lexer.Token
 	Initializer Expr
@@ -91,8 +168,10 @@ func (n *Var) Accept(v VisitorStmt[any]) (any, error) {
 }
 
 type While struct {
+	Keyword   *lexer.Token
 	Condition Expr
 	Body      Stmt
+	Increment Expr
 }
 
 func (n *While) Accept(v VisitorStmt[any]) (any, error) {
@@ -2,23 +2,30 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/dydev10/glox/glox"
+	"github.com/dydev10/glox/interpreter"
 )
 
+var maxCallDepth = flag.Int("max-call-depth", interpreter.DefaultMaxCallDepth, "nesting of calls before stack overflow error, 0 disables the limit")
+
 func main() {
-	if len(os.Args) == 1 {
+	flag.Parse()
+	args := flag.Args()
+
+	if len(args) == 0 {
 		startREPL()
 		os.Exit(0)
-	} else if len(os.Args) == 3 {
-		runFile(os.Args[1], os.Args[2])
+	} else if len(args) == 2 {
+		runFile(args[0], args[1])
 	}
 
-	if len(os.Args) < 3 {
-		fmt.Fprintln(os.Stderr, "Usage: ./your_program.sh tokenize <filename>")
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: ./your_program.sh [-max-call-depth n] tokenize <filename>")
 		os.Exit(1)
 	}
 }
@@ -42,7 +49,7 @@ func runFile(command, filename string) {
 		os.Exit(1)
 	}
 
-	glox := glox.NewGlox(command, string(fileContents))
+	glox := glox.NewGlox(command, string(fileContents), glox.WithPath(filename), glox.WithMaxCallDepth(*maxCallDepth))
 	glox.Tokenize()
 
 	if command == "parse" || command == "evaluate" {
@@ -86,7 +93,7 @@ func REPL() {
 		command = "evaluate"
 	}
 
-	glox := glox.NewGlox(command, source)
+	glox := glox.NewGlox(command, source, glox.WithMaxCallDepth(*maxCallDepth))
 	glox.Tokenize()
 
 	if command == "run" {
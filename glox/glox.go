@@ -1,7 +1,9 @@
 package glox
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/dydev10/glox/ast"
@@ -11,10 +13,18 @@ import (
 )
 
 type Glox struct {
-	source     string
-	command    string
-	isRunMode  bool
-	isEvalMode bool
+	source       string
+	path         string
+	maxCallDepth int
+	stepBudget   int
+	memoryLimit  int
+	natives      []interpreter.Option
+	ctx          context.Context
+	stdout       io.Writer
+	stderr       io.Writer
+	command      string
+	isRunMode    bool
+	isEvalMode   bool
 
 	HadSyntaxError  bool
 	HadResolveError bool
@@ -26,17 +36,109 @@ type Glox struct {
 	statements []ast.Stmt
 
 	evaluation any
+	intr       *interpreter.Interpreter
 }
 
-func NewGlox(command, source string) *Glox {
-	return &Glox{
-		source:     source,
-		command:    command,
-		isRunMode:  command == "run",
-		isEvalMode: command == "evaluate",
+type Option func(g *Glox)
+
+// path of script file being run, used to resolve its imports
+func WithPath(path string) Option {
+	return func(g *Glox) {
+		g.path = path
+	}
+}
+
+// limit nesting of lox calls, 0 disables the limit
+func WithMaxCallDepth(depth int) Option {
+	return func(g *Glox) {
+		g.maxCallDepth = depth
+	}
+}
+
+// limit loop iterations and calls script can run, 0 disables the limit
+func WithStepBudget(steps int) Option {
+	return func(g *Glox) {
+		g.stepBudget = steps
+	}
+}
+
+// limit approximate bytes of values script holds at once, 0 disables the limit.
+// see interpreter.WithMemoryLimit
+func WithMemoryLimit(bytes int) Option {
+	return func(g *Glox) {
+		g.memoryLimit = bytes
+	}
+}
+
+// expose host function to script as global, see Interpreter.DefineNative
+func WithNative(name string, arity int, fn func(args []interpreter.Value) (interpreter.Value, error)) Option {
+	return func(g *Glox) {
+		g.natives = append(g.natives, interpreter.WithNative(name, arity, fn))
+	}
+}
+
+// stream for script output and command results, os.Stdout by default
+func WithStdout(stdout io.Writer) Option {
+	return func(g *Glox) {
+		g.stdout = stdout
+	}
+}
+
+// stream for error reports, os.Stderr by default
+func WithStderr(stderr io.Writer) Option {
+	return func(g *Glox) {
+		g.stderr = stderr
+	}
+}
+
+// context for running script, cancelling it or passing its deadline stops the script
+func WithContext(ctx context.Context) Option {
+	return func(g *Glox) {
+		g.ctx = ctx
 	}
 }
 
+func NewGlox(command, source string, options ...Option) *Glox {
+	g := &Glox{
+		source:       source,
+		maxCallDepth: interpreter.DefaultMaxCallDepth,
+		ctx:          context.Background(),
+		stdout:       os.Stdout,
+		stderr:       os.Stderr,
+		command:      command,
+		isRunMode:    command == "run",
+		isEvalMode:   command == "evaluate",
+	}
+
+	for _, option := range options {
+		option(g)
+	}
+
+	return g
+}
+
+func (g *Glox) interpreterOptions() []interpreter.Option {
+	options := []interpreter.Option{}
+	if g.path != "" {
+		options = append(options, interpreter.WithScriptPath(g.path))
+	}
+	options = append(options, interpreter.WithMaxCallDepth(g.maxCallDepth))
+	options = append(options, interpreter.WithStepBudget(g.stepBudget))
+	options = append(options, interpreter.WithMemoryLimit(g.memoryLimit))
+	options = append(options, interpreter.WithStdout(g.stdout))
+	options = append(options, g.natives...)
+	return options
+}
+
+// interpreter which runs source, created on first use so that host can set globals before running
+// and look up globals or call script functions after
+func (g *Glox) Interpreter() *interpreter.Interpreter {
+	if g.intr == nil {
+		g.intr = interpreter.NewInterpreter(g.interpreterOptions()...)
+	}
+	return g.intr
+}
+
 func (g *Glox) Tokenize() {
 	//lexer
 	l := lexer.New(g.source)
@@ -53,19 +155,16 @@ func (g *Glox) RunStatements() {
 	}
 
 	p := parser.NewParser(g.tokens)
-	statements, parseError := p.Parse()
+	statements, _ := p.Parse()
 	g.statements = statements
-	if parseError != nil {
-		g.HadSyntaxError = true
-		g.errorList = append(g.errorList, parseError)
-	}
+	g.addParseErrors(p.Errors)
 
 	// end execution if only parse command
 	if !g.isRunMode || g.HadSyntaxError {
 		return
 	}
 
-	intr := interpreter.NewInterpreter()
+	intr := g.Interpreter()
 	resolver := interpreter.NewResolver(intr)
 
 	resolver.Resolve(g.statements)
@@ -76,7 +175,7 @@ func (g *Glox) RunStatements() {
 		return
 	}
 
-	runtimeErr := intr.Interpret(statements)
+	runtimeErr := intr.Interpret(g.ctx, statements)
 	if runtimeErr != nil {
 		g.HadRuntimeError = true
 		g.errorList = append(g.errorList, runtimeErr)
@@ -89,18 +188,15 @@ func (g *Glox) RunExpression() {
 	}
 
 	p := parser.NewParser(g.tokens)
-	expression, parseError := p.ParseExpression()
+	expression, _ := p.ParseExpression()
 	g.expression = expression
-	if parseError != nil {
-		g.HadSyntaxError = true
-		g.errorList = append(g.errorList, parseError)
-	}
+	g.addParseErrors(p.Errors)
 
 	// end execution if only parse command
 	if !g.isEvalMode || g.HadSyntaxError {
 		return
 	}
-	intr := interpreter.NewInterpreter()
+	intr := g.Interpreter()
 	evaluation, runtimeErr := intr.EvaluateExpression(expression)
 	g.evaluation = evaluation
 	if runtimeErr != nil {
@@ -109,9 +205,23 @@ func (g *Glox) RunExpression() {
 	}
 }
 
+func (g *Glox) addParseErrors(errors []*parser.ParseError) {
+	if len(errors) == 0 {
+		return
+	}
+	g.HadSyntaxError = true
+	for _, err := range errors {
+		g.errorList = append(g.errorList, err)
+	}
+}
+
 func (g *Glox) PrintErrors() {
 	for _, lexError := range g.errorList {
-		fmt.Fprintln(os.Stderr, lexError)
+		fmt.Fprintln(g.stderr, lexError)
+		// runtime errors raised inside functions also show call stack
+		if traced, ok := lexError.(interpreter.Traced); ok {
+			fmt.Fprint(g.stderr, traced.Traceback())
+		}
 	}
 }
 
@@ -119,18 +229,18 @@ func (g *Glox) PrintResult() {
 	switch g.command {
 	case "tokenize":
 		for _, v := range g.tokens {
-			fmt.Println(v.String())
+			fmt.Fprintln(g.stdout, v.String())
 		}
 	case "parse":
 		if !g.HadSyntaxError {
 			astPrinter := &ast.Printer{}
 			out := astPrinter.Print(g.expression)
-			fmt.Printf("%s", out)
+			fmt.Fprintf(g.stdout, "%s", out)
 		}
 	case "evaluate":
 		if !g.HadSyntaxError && !g.HadRuntimeError {
 			evalOut := interpreter.PrintEvaluation(g.evaluation)
-			fmt.Printf("%s", evalOut)
+			fmt.Fprintf(g.stdout, "%s", evalOut)
 		}
 	}
 }
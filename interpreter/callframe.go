@@ -0,0 +1,71 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// CallFrame is one active call of lox function or class, kept on interpreter call stack for tracebacks
+type CallFrame struct {
+	name     string
+	callSite *lexer.Token // token where call was made in caller frame
+}
+
+// call site of calls made by host program through Interpreter.Call
+var hostCallSite = &lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "<host>"}
+
+// errors which carry traceback of call stack active when they were raised
+type Traced interface {
+	Traceback() string
+}
+
+func (intr *Interpreter) enterFrame(name string) {
+	intr.frames.Push(&CallFrame{
+		name:     name,
+		callSite: intr.callSite,
+	})
+}
+
+// pop current frame, error leaving its first frame captures whole stack as traceback
+func (intr *Interpreter) leaveFrame(err error) error {
+	var runtimeErr *RuntimeError
+	var thrownErr *ThrownError
+	if errors.As(err, &runtimeErr) && runtimeErr.traceback == "" {
+		runtimeErr.traceback = intr.traceback(runtimeErr.token.Line)
+	} else if errors.As(err, &thrownErr) && thrownErr.traceback == "" {
+		thrownErr.traceback = intr.traceback(thrownErr.token.Line)
+	}
+
+	intr.frames.Pop()
+	return err
+}
+
+// frames shown at each end of long traceback, deep recursion would print thousands of lines otherwise
+const tracebackEdge = 10
+
+// format active frames innermost first, each line is where its frame was executing
+func (intr *Interpreter) traceback(line int) string {
+	var builder strings.Builder
+
+	depth := intr.frames.Len()
+	for i := depth - 1; i >= 0; i-- {
+		frame := intr.frames.Get(i)
+		shown := depth-1-i < tracebackEdge || i < tracebackEdge
+		if shown {
+			fmt.Fprintf(&builder, "[line %d] in %s()\n", line, frame.name)
+		} else if i == tracebackEdge {
+			fmt.Fprintf(&builder, "... %d more frames\n", depth-2*tracebackEdge)
+		}
+		if frame.callSite == hostCallSite {
+			builder.WriteString("called from host\n")
+			return builder.String()
+		}
+		line = frame.callSite.Line
+	}
+	fmt.Fprintf(&builder, "[line %d] in script\n", line)
+
+	return builder.String()
+}
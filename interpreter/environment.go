@@ -2,7 +2,6 @@ package interpreter
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/dydev10/glox/lexer"
 )
@@ -44,15 +43,26 @@ func (env *Environment) get(name *lexer.Token) (any, error) {
 	}
 }
 
-func (env *Environment) getAt(distance int, name string) (any, error) {
-	value, ok := env.ancestor(distance).values[name]
+func (env *Environment) getAt(distance int, name *lexer.Token) (any, error) {
+	value, ok := env.ancestor(distance).values[name.Lexeme]
 	if !ok {
-		// TODO: accept token instead of token's name and return runtime error here when variable not found in environment
-		fmt.Fprintf(os.Stderr, "Unhandled Error: getAt function did not find variable %s definition in given depth %d", name, distance)
+		// only reachable when resolver computed wrong depth for variable
+		return nil, &RuntimeError{
+			token:   name,
+			message: fmt.Sprintf("Undefined variable %s.", name.Lexeme),
+		}
 	}
 	return value, nil
 }
 
+// implicit 'this' has no token of its own, it is reported at given token's position
+func thisToken(at *lexer.Token) *lexer.Token {
+	this := *at
+	this.Type = lexer.THIS
+	this.Lexeme = "this"
+	return &this
+}
+
 func (env *Environment) assign(name *lexer.Token, value any) error {
 	if _, ok := env.values[name.Lexeme]; ok {
 		env.values[name.Lexeme] = value
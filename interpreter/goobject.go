@@ -0,0 +1,192 @@
+package interpreter
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// GoObject exposes Go struct pointer to scripts, its exported fields and methods are lox properties
+type GoObject struct {
+	value reflect.Value
+}
+
+// GoMethod is exported method of Go value bound to its receiver
+type GoMethod struct {
+	name   string
+	method reflect.Value
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// bind Go struct pointer as global object visible to main script and every module
+func (intr *Interpreter) DefineObject(name string, object any) error {
+	value := reflect.ValueOf(object)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("can only define non-nil struct pointer as object, got %T", object)
+	}
+
+	intr.builtins.define(name, &GoObject{value: value})
+	return nil
+}
+
+func (o *GoObject) Get(intr *Interpreter, name *lexer.Token) (any, error) {
+	field, fieldErr := o.field(name)
+	if fieldErr != nil {
+		return nil, fieldErr
+	}
+	if field.IsValid() {
+		value, err := toValue(field)
+		if err != nil {
+			return nil, &RuntimeError{
+				token:   name,
+				message: fmt.Sprintf("Can't read field '%s': %s.", name.Lexeme, err),
+			}
+		}
+		return value, nil
+	}
+
+	if method := o.value.MethodByName(name.Lexeme); method.IsValid() {
+		return &GoMethod{name: name.Lexeme, method: method}, nil
+	}
+
+	return nil, &RuntimeError{
+		token:   name,
+		message: fmt.Sprintf("Undefined property '%s'.", name.Lexeme),
+	}
+}
+
+func (o *GoObject) Set(intr *Interpreter, name *lexer.Token, value any) error {
+	field, fieldErr := o.field(name)
+	if fieldErr != nil {
+		return fieldErr
+	}
+	if !field.IsValid() || !field.CanSet() {
+		return &RuntimeError{
+			token:   name,
+			message: fmt.Sprintf("Undefined field '%s'.", name.Lexeme),
+		}
+	}
+
+	converted, err := fromValue(value, field.Type())
+	if err != nil {
+		return &RuntimeError{
+			token:   name,
+			message: fmt.Sprintf("Can't assign to field '%s': %s.", name.Lexeme, err),
+		}
+	}
+
+	field.Set(converted)
+	return nil
+}
+
+// exported field of struct, invalid value if there is none.
+// field promoted from nil embedded pointer can't be reached
+func (o *GoObject) field(name *lexer.Token) (reflect.Value, error) {
+	structField, ok := o.value.Elem().Type().FieldByName(name.Lexeme)
+	if !ok || !structField.IsExported() {
+		return reflect.Value{}, nil
+	}
+
+	field, err := o.value.Elem().FieldByIndexErr(structField.Index)
+	if err != nil {
+		return reflect.Value{}, &RuntimeError{
+			token:   name,
+			message: fmt.Sprintf("Can't reach field '%s' through nil embedded struct.", name.Lexeme),
+		}
+	}
+	return field, nil
+}
+
+func (o *GoObject) String() string {
+	return fmt.Sprintf("<go %s>", o.value.Type())
+}
+
+func (m *GoMethod) Arity() int {
+	if m.method.Type().IsVariadic() {
+		return VariadicArity
+	}
+	return m.method.Type().NumIn()
+}
+
+func (m *GoMethod) Call(intr *Interpreter, arguments []any) (result any, err error) {
+	callSite := intr.callSite
+	methodType := m.method.Type()
+
+	// variadic method still needs its fixed parameters
+	if methodType.IsVariadic() && len(arguments) < methodType.NumIn()-1 {
+		return nil, &RuntimeError{
+			token:   callSite,
+			message: fmt.Sprintf("Expected at least %d arguments but got %d.", methodType.NumIn()-1, len(arguments)),
+		}
+	}
+
+	in := make([]reflect.Value, len(arguments))
+	for i, argument := range arguments {
+		paramType := paramType(methodType, i)
+		converted, convErr := fromValue(argument, paramType)
+		if convErr != nil {
+			return nil, &RuntimeError{
+				token:   callSite,
+				message: fmt.Sprintf("Invalid argument %d to '%s': %s.", i+1, m.name, convErr),
+			}
+		}
+		in[i] = converted
+	}
+
+	// panic in host code must not take down whole process
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result, err = nil, &RuntimeError{
+				token:   callSite,
+				message: fmt.Sprintf("Go method '%s' panicked: %v", m.name, recovered),
+			}
+		}
+	}()
+
+	return goResults(callSite, m.name, m.method.Call(in))
+}
+
+func (m *GoMethod) String() string {
+	return fmt.Sprintf("<go method %s>", m.name)
+}
+
+// type of i-th argument, variadic arguments take element type of last parameter
+func paramType(methodType reflect.Type, i int) reflect.Type {
+	if methodType.IsVariadic() && i >= methodType.NumIn()-1 {
+		return methodType.In(methodType.NumIn() - 1).Elem()
+	}
+	return methodType.In(i)
+}
+
+// convert return values of Go call. trailing error is raised, single value is returned as is,
+// several values become list
+func goResults(callSite *lexer.Token, name string, out []reflect.Value) (any, error) {
+	if len(out) > 0 && out[len(out)-1].Type() == errorType {
+		if errValue := out[len(out)-1]; !errValue.IsNil() {
+			return nil, nativeError(callSite, errValue.Interface().(error))
+		}
+		out = out[:len(out)-1]
+	}
+
+	values := make([]any, len(out))
+	for i, result := range out {
+		value, err := toValue(result)
+		if err != nil {
+			return nil, &RuntimeError{
+				token:   callSite,
+				message: fmt.Sprintf("Go method '%s' returned %s.", name, err),
+			}
+		}
+		values[i] = value
+	}
+
+	switch len(values) {
+	case 0:
+		return nil, nil
+	case 1:
+		return values[0], nil
+	}
+	return &LoxList{elements: values}, nil
+}
@@ -0,0 +1,181 @@
+package interpreter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dydev10/glox/interpreter"
+	"github.com/dydev10/glox/lexer"
+	"github.com/dydev10/glox/parser"
+)
+
+type inner struct {
+	X int
+}
+
+type Base struct {
+	ID int
+}
+
+type config struct {
+	*Base
+	Name  string
+	Port  int
+	Tags  []string
+	Inner inner
+	Home  *inner
+	token string
+}
+
+func (c *config) Greet(who string) string {
+	return "hi " + who + " from " + c.Name
+}
+
+func (c *config) Sum(nums ...int) int {
+	total := 0
+	for _, n := range nums {
+		total += n
+	}
+	return total
+}
+
+func (c *config) Check(n int) (bool, error) {
+	if n < 0 {
+		return false, errors.New("negative")
+	}
+	return true, nil
+}
+
+// lex, parse, resolve and run source, output of print statements is returned
+func run(t *testing.T, intr *interpreter.Interpreter, stdout *bytes.Buffer, source string) (string, error) {
+	t.Helper()
+
+	l := lexer.New(source)
+	tokens := l.Lex()
+	if len(l.Errors) > 0 {
+		t.Fatalf("lex errors: %v", l.Errors)
+	}
+
+	p := parser.NewParser(tokens)
+	statements, err := p.Parse()
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	resolver := interpreter.NewResolver(intr)
+	resolver.Resolve(statements)
+	if len(resolver.Errors) > 0 {
+		t.Fatalf("resolve errors: %v", resolver.Errors)
+	}
+
+	stdout.Reset()
+	runErr := intr.Interpret(context.Background(), statements)
+	return stdout.String(), runErr
+}
+
+func newTestInterpreter(opts ...interpreter.Option) (*interpreter.Interpreter, *bytes.Buffer) {
+	var stdout bytes.Buffer
+	return interpreter.NewInterpreter(append(opts, interpreter.WithStdout(&stdout))...), &stdout
+}
+
+func TestToValueAndFromValue(t *testing.T) {
+	value, err := interpreter.ToValue(map[string][]int{"b": {2, 3}, "a": {1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := interpreter.PrintEvaluation(value); got != "{a: [1], b: [2, 3]}" {
+		t.Errorf("ToValue map = %s", got)
+	}
+
+	var back map[string][]int
+	if err := interpreter.FromValue(value, &back); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string][]int{"a": {1}, "b": {2, 3}}; !reflect.DeepEqual(back, want) {
+		t.Errorf("FromValue = %v, want %v", back, want)
+	}
+
+	var n int
+	if err := interpreter.FromValue(1.5, &n); err == nil {
+		t.Error("FromValue of fractional number into int should fail")
+	}
+	var s string
+	if err := interpreter.FromValue(1.0, &s); err == nil {
+		t.Error("FromValue of number into string should fail")
+	}
+	if _, err := interpreter.ToValue(make(chan int)); err == nil {
+		t.Error("ToValue of channel should fail")
+	}
+
+	self := map[string]any{"n": 1}
+	self["self"] = self
+	list := []any{1, nil}
+	list[1] = list
+	for _, cyclic := range []any{self, list} {
+		if _, err := interpreter.ToValue(cyclic); err == nil || !strings.Contains(err.Error(), "contains itself") {
+			t.Errorf("ToValue of self-containing %T: err = %v", cyclic, err)
+		}
+	}
+
+	shared := []int{1}
+	value, err = interpreter.ToValue(map[string][]int{"a": shared, "b": shared})
+	if err != nil {
+		t.Errorf("value shared without cycle should convert: %v", err)
+	}
+}
+
+func TestDefineObject(t *testing.T) {
+	intr, stdout := newTestInterpreter()
+	cfg := &config{Base: &Base{ID: 7}, Name: "svc", Port: 80, Tags: []string{"a"}, Home: &inner{X: 1}}
+	if err := intr.DefineObject("cfg", cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run(t, intr, stdout, `
+print cfg.Name;
+print cfg.ID;
+print cfg.Tags;
+print cfg.Greet("bob");
+print cfg.Sum(1, 2, 3);
+try { cfg.Check(-1); } catch (e) { print e.message; }
+cfg.Port = 8080;
+cfg.Inner.X = 5;
+cfg.Home.X = 6;
+cfg.Tags = ["x", "y"];
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "svc\n7\n[a]\nhi bob from svc\n6\nnegative\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+	if cfg.Port != 8080 || cfg.Inner.X != 5 || cfg.Home.X != 6 || !reflect.DeepEqual(cfg.Tags, []string{"x", "y"}) {
+		t.Errorf("script writes not seen by host: %+v", cfg)
+	}
+
+	for _, source := range []string{`cfg.Port = 1.5;`, `cfg.Port = "x";`, `print cfg.token;`} {
+		if _, err := run(t, intr, stdout, source); err == nil {
+			t.Errorf("%s should fail", source)
+		}
+	}
+
+	if err := intr.DefineObject("bad", 3); err == nil {
+		t.Error("DefineObject of non-pointer should fail")
+	}
+}
+
+func TestDefineObjectNilEmbedded(t *testing.T) {
+	intr, stdout := newTestInterpreter()
+	if err := intr.DefineObject("o", &config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := run(t, intr, stdout, `print o.ID;`)
+	if err == nil || !strings.Contains(err.Error(), "nil embedded struct") {
+		t.Errorf("err = %v, want nil embedded struct error", err)
+	}
+}
@@ -1,51 +1,142 @@
 package interpreter
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/dydev10/glox/ast"
+	"github.com/dydev10/glox/ds"
 	"github.com/dydev10/glox/lexer"
 )
 
 type Interpreter struct {
+	builtins    *Environment
 	globals     *Environment
 	environment *Environment
 	locals      map[ast.Expr]int
+
+	module  *LoxModule            // module of running code, globals is its top level scope
+	modules map[string]*LoxModule // imported modules by absolute path
+	loading *ds.Stack[string]     // paths of modules being loaded, used to detect import cycles
+
+	frames       *ds.Stack[*CallFrame]   // active lox calls, innermost on top
+	scopes       *ds.Stack[*Environment] // environments suspended by blocks and calls being run
+	callSite     *lexer.Token            // token of call being made, recorded by next frame
+	maxCallDepth int                     // calls nested deeper than this raise stack overflow, 0 means no limit
+
+	ctx        context.Context // checked on every step, cancelling it stops running script
+	running    bool            // Interpret or host Call is running, nested host calls keep its limits
+	steps      int             // loop iterations and calls run by current Interpret
+	stepBudget int             // steps allowed per Interpret, 0 means no limit
+
+	allocated   int // approximate bytes live at last measure plus bytes allocated since
+	memoryLimit int // live bytes allowed, 0 means no limit
+
+	stdout io.Writer // print statements write here
+}
+
+// deep enough for ordinary recursion, well below depth where go runtime itself runs out of stack
+const DefaultMaxCallDepth = 10000
+
+type Option func(intr *Interpreter)
+
+// stream print statements write to, os.Stdout by default
+func WithStdout(stdout io.Writer) Option {
+	return func(intr *Interpreter) {
+		intr.stdout = stdout
+	}
+}
+
+// limit approximate bytes of strings, lists, maps, instances, functions and environments script holds at once,
+// 0 disables the limit. temporary values stop counting once script can no longer reach them
+func WithMemoryLimit(bytes int) Option {
+	return func(intr *Interpreter) {
+		intr.memoryLimit = bytes
+	}
+}
+
+// limit loop iterations and calls each Interpret can run, 0 disables the limit
+func WithStepBudget(steps int) Option {
+	return func(intr *Interpreter) {
+		intr.stepBudget = steps
+	}
+}
+
+// limit nesting of lox calls, 0 disables the limit
+func WithMaxCallDepth(depth int) Option {
+	return func(intr *Interpreter) {
+		intr.maxCallDepth = depth
+	}
 }
 
-func NewInterpreter() *Interpreter {
-	globals := NewEnvironment(nil)
+// path of main script, its imports are resolved relative to its directory
+func WithScriptPath(path string) Option {
+	return func(intr *Interpreter) {
+		intr.module.path = path
+		intr.module.name = path
+		// importing main script back from a module is a cycle
+		if absPath, err := filepath.Abs(path); err == nil {
+			intr.loading.Push(absPath)
+		}
+	}
+}
+
+func NewInterpreter(options ...Option) *Interpreter {
+	builtins := NewEnvironment(nil)
+
+	// main script is a module too, builtins scope is shared with every imported module
+	main := newLoxModule("", "", builtins)
+
+	intr := &Interpreter{
+		builtins:     builtins,
+		globals:      main.globals,
+		environment:  main.globals,
+		locals:       make(map[ast.Expr]int),
+		module:       main,
+		modules:      make(map[string]*LoxModule),
+		loading:      ds.NewStack[string](),
+		frames:       ds.NewStack[*CallFrame](),
+		scopes:       ds.NewStack[*Environment](),
+		maxCallDepth: DefaultMaxCallDepth,
+		ctx:          context.Background(),
+		stdout:       os.Stdout,
+	}
 
-	globals.define("clock", &Clock{})
+	intr.DefineNative("clock", 0, func(args []Value) (Value, error) {
+		return float64(time.Now().Unix()), nil
+	})
 
-	return &Interpreter{
-		globals:     globals,
-		environment: globals,
-		locals:      make(map[ast.Expr]int),
+	for _, option := range options {
+		option(intr)
 	}
+
+	return intr
 }
 
+// text of value as print statement shows it, instances print in plain form since no interpreter runs their __str
 func PrintEvaluation(val any) string {
-	switch v := val.(type) {
-	case string:
-		return v
-	case float64:
-		return strconv.FormatFloat(v, 'f', -1, 64) // no .0 needed at end
-	case bool:
-		return strconv.FormatBool(v)
-	case fmt.Stringer:
-		return v.String()
-	case nil:
-		return "nil"
-	default:
-		e := fmt.Sprintf("Unknown value type evaluated by interpreter: %v", v)
-		panic(e)
-	}
-}
-
-// main entry point to run glox statements
-func (intr *Interpreter) Interpret(statements []ast.Stmt) error {
+	// without interpreter nothing can fail
+	str, _ := newStringifier(nil, nil).stringify(val)
+	return str
+}
+
+// main entry point to run glox statements, cancelling ctx stops script with InterruptError
+func (intr *Interpreter) Interpret(ctx context.Context, statements []ast.Stmt) error {
+	intr.ctx = ctx
+	intr.steps = 0
+	intr.allocated = 0
+	intr.running = true
+	defer func() {
+		intr.ctx = context.Background()
+		intr.running = false
+	}()
+
 	for _, stmt := range statements {
 		_, err := intr.execute(stmt)
 		if err != nil {
@@ -61,6 +152,64 @@ func (intr *Interpreter) EvaluateExpression(expr ast.Expr) (any, error) {
 	return intr.evaluate(expr)
 }
 
+// look up global variable of main script, builtins and native functions included
+func (intr *Interpreter) GetGlobal(name string) (Value, bool) {
+	for env := intr.globals; env != nil; env = env.enclosing {
+		if value, ok := env.values[name]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// define global variable of main script, value is converted with ToValue
+func (intr *Interpreter) SetGlobal(name string, value any) error {
+	converted, err := ToValue(value)
+	if err != nil {
+		return err
+	}
+	intr.globals.define(name, converted)
+	return nil
+}
+
+// call lox function, class or native function from Go, arguments are converted with ToValue.
+// like Interpret, each call from host gets its own step budget and memory limit
+func (intr *Interpreter) Call(ctx context.Context, callee Value, arguments ...any) (Value, error) {
+	function, ok := callee.(LoxCallable)
+	if !ok {
+		return nil, fmt.Errorf("can only call functions and classes, got %s", typeName(callee))
+	}
+	if function.Arity() != VariadicArity && function.Arity() != len(arguments) {
+		return nil, fmt.Errorf("expected %d arguments but got %d", function.Arity(), len(arguments))
+	}
+
+	values := make([]any, len(arguments))
+	for i, argument := range arguments {
+		value, err := ToValue(argument)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i+1, err)
+		}
+		values[i] = value
+	}
+
+	// native function calling back into lox keeps limits of running script,
+	// nested call stops when either script's context or its own one is done
+	prevCtx, prevRunning := intr.ctx, intr.running
+	defer func() { intr.ctx, intr.running = prevCtx, prevRunning }()
+	if intr.running {
+		nestedCtx, cancel := mergeContext(prevCtx, ctx)
+		defer cancel()
+		intr.ctx = nestedCtx
+	} else {
+		intr.ctx = ctx
+		intr.steps = 0
+		intr.allocated = 0
+		intr.running = true
+	}
+
+	return intr.call(hostCallSite, function, values)
+}
+
 func (intr *Interpreter) execute(stmt ast.Stmt) (any, error) {
 	return stmt.Accept(intr)
 }
@@ -76,25 +225,27 @@ func (intr *Interpreter) resolve(expr ast.Expr, depth int) {
 func (intr *Interpreter) executeBlock(statements []ast.Stmt, env *Environment) error {
 	prevEnv := intr.environment
 	intr.environment = env
+	// suspended environment is still live, memory measuring finds it here
+	intr.scopes.Push(prevEnv)
 
 	for _, stmt := range statements {
 		_, err := intr.execute(stmt)
 		if err != nil {
 			// restore original environment on error
-			intr.environment = prevEnv
+			intr.environment = intr.scopes.Pop()
 			return err
 		}
 	}
 
 	// restore original environment before returning
-	intr.environment = prevEnv
+	intr.environment = intr.scopes.Pop()
 	return nil
 }
 
 func (intr *Interpreter) lookupVariable(name *lexer.Token, expr ast.Expr) (any, error) {
 	distance, ok := intr.locals[expr]
 	if ok {
-		return intr.environment.getAt(distance, name.Lexeme)
+		return intr.environment.getAt(distance, name)
 	} else {
 		return intr.globals.get(name)
 	}
@@ -134,8 +285,8 @@ func (intr *Interpreter) isEqual(a, b any) bool {
 	if isBoolA && isBoolB {
 		return aBool == bBool
 	}
-	// type mismatch, mean inequality
-	return false
+	// remaining values are references, equal only to themselves. type mismatch means inequality
+	return a == b
 }
 
 func checkNumberOperand(operator *lexer.Token, operand any) error {
@@ -160,6 +311,23 @@ func checkNumberOperands(operator *lexer.Token, left any, right any) error {
 * Expr interface implementation
  */
 
+func (intr *Interpreter) VisitList(expr *ast.List) (any, error) {
+	elements := make([]any, 0, len(expr.Elements))
+	for _, element := range expr.Elements {
+		value, err := intr.evaluate(element)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, value)
+	}
+
+	if err := intr.allocate(expr.Bracket, objectSize+len(elements)*valueSize); err != nil {
+		return nil, err
+	}
+
+	return &LoxList{elements: elements}, nil
+}
+
 func (intr *Interpreter) VisitLiteral(expr *ast.Literal) (any, error) {
 	return expr.Value, nil
 }
@@ -184,14 +352,38 @@ func (intr *Interpreter) VisitLogical(expr *ast.Logical) (any, error) {
 	return intr.evaluate(expr.Right)
 }
 
+func (intr *Interpreter) VisitMap(expr *ast.Map) (any, error) {
+	if err := intr.allocate(expr.Brace, objectSize+len(expr.Keys)*entrySize); err != nil {
+		return nil, err
+	}
+
+	m := NewLoxMap()
+	for i := range expr.Keys {
+		key, keyErr := intr.evaluate(expr.Keys[i])
+		if keyErr != nil {
+			return nil, keyErr
+		}
+		value, valueErr := intr.evaluate(expr.Values[i])
+		if valueErr != nil {
+			return nil, valueErr
+		}
+
+		if err := m.SetIndex(expr.Brace, key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
 func (intr *Interpreter) VisitSet(expr *ast.Set) (any, error) {
 	object, objErr := intr.evaluate(expr.Object)
 	if objErr != nil {
 		return nil, objErr
 	}
 
-	instance, isInstance := object.(*LoxInstance)
-	if !isInstance {
+	assignable, isAssignable := object.(LoxAssignable)
+	if !isAssignable {
 		return nil, &RuntimeError{
 			token:   expr.Name,
 			message: "Only instances have fields.",
@@ -203,21 +395,23 @@ func (intr *Interpreter) VisitSet(expr *ast.Set) (any, error) {
 		return nil, valueErr
 	}
 
-	instance.Set(expr.Name, value)
+	if err := assignable.Set(intr, expr.Name, value); err != nil {
+		return nil, err
+	}
 
 	return value, nil
 }
 
 func (intr *Interpreter) VisitSuper(expr *ast.Super) (any, error) {
-	// errors/nil values are not handled here because resolver should report static errors for cases which can lead to nil value here
-	// if any panics from this function, then check resolver, or VisitClass handler which sets these values in environment
-	distance := intr.locals[expr]
-
-	superRef, _ := intr.environment.getAt(distance, "super")
-	superclass := superRef.(*LoxClass)
+	superclass, object, err := intr.superTarget(expr, expr.Keyword)
+	if err != nil {
+		return nil, err
+	}
 
-	objectRef, _ := intr.environment.getAt(distance-1, "this")
-	object := objectRef.(*LoxInstance)
+	// overriding getter reaches parent getter through super, same lookup order as instance property access
+	if getter := superclass.FindGetter(expr.Method.Lexeme); getter != nil {
+		return intr.call(expr.Method, getter.Bind(object), []any{})
+	}
 
 	method := superclass.FindMethod(expr.Method.Lexeme)
 	if method == nil {
@@ -227,7 +421,69 @@ func (intr *Interpreter) VisitSuper(expr *ast.Super) (any, error) {
 		}
 	}
 
-	return method.Bind(object), nil
+	return intr.bindMethod(expr.Method, method, object)
+}
+
+func (intr *Interpreter) VisitSuperSet(expr *ast.SuperSet) (any, error) {
+	superclass, object, err := intr.superTarget(expr, expr.Keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	setter := superclass.FindSetter(expr.Name.Lexeme)
+	if setter == nil {
+		return nil, &RuntimeError{
+			token:   expr.Name,
+			message: fmt.Sprintf("Undefined setter '%s' in superclass.", expr.Name.Lexeme),
+		}
+	}
+
+	value, valueErr := intr.evaluate(expr.Value)
+	if valueErr != nil {
+		return nil, valueErr
+	}
+
+	if _, err := intr.call(expr.Name, setter.Bind(object), []any{value}); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// superclass where super lookup starts and 'this' which found member is bound to
+func (intr *Interpreter) superTarget(expr ast.Expr, keyword *lexer.Token) (*LoxClass, any, error) {
+	// resolver reports static errors for cases which can lead to missing 'super' or 'this' here
+	// if any panics from this function, then check resolver, or VisitClass handler which sets these values in environment
+	distance := intr.locals[expr]
+
+	superRef, err := intr.environment.getAt(distance, keyword)
+	if err != nil {
+		return nil, nil, err
+	}
+	superclass := superRef.(*LoxClass)
+
+	// 'this' is the class itself inside class methods, then super lookup starts at superclass's metaclass
+	object, err := intr.environment.getAt(distance-1, thisToken(keyword))
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, isClass := object.(*LoxClass); isClass {
+		superclass = superclass.metaclass
+	}
+
+	return superclass, object, nil
+}
+
+func (intr *Interpreter) VisitTernary(expr *ast.Ternary) (any, error) {
+	cond, err := intr.evaluate(expr.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	// only evaluate the selected branch
+	if intr.isTruthy(cond) {
+		return intr.evaluate(expr.ThenBranch)
+	}
+	return intr.evaluate(expr.ElseBranch)
 }
 
 func (intr *Interpreter) VisitThis(expr *ast.This) (any, error) {
@@ -238,6 +494,40 @@ func (intr *Interpreter) VisitGrouping(expr *ast.Grouping) (any, error) {
 	return intr.evaluate(expr.Expression)
 }
 
+func (intr *Interpreter) VisitInterpolation(expr *ast.Interpolation) (any, error) {
+	var builder strings.Builder
+
+	for _, part := range expr.Parts {
+		val, err := intr.evaluate(part)
+		if err != nil {
+			return nil, err
+		}
+		str, err := intr.stringify(expr.Start, val)
+		if err != nil {
+			return nil, err
+		}
+		builder.WriteString(str)
+	}
+
+	if err := intr.allocate(expr.Start, builder.Len()); err != nil {
+		return nil, err
+	}
+
+	return builder.String(), nil
+}
+
+func (intr *Interpreter) VisitLambda(expr *ast.Lambda) (any, error) {
+	if err := intr.allocate(expr.Keyword, objectSize); err != nil {
+		return nil, err
+	}
+	return &LoxFunction{
+		declaration:   expr.Function,
+		closure:       intr.environment,
+		module:        intr.module,
+		isInitializer: false,
+	}, nil
+}
+
 func (intr *Interpreter) VisitUnary(expr *ast.Unary) (any, error) {
 	right, err := intr.evaluate(expr.Right)
 	if err != nil {
@@ -246,6 +536,9 @@ func (intr *Interpreter) VisitUnary(expr *ast.Unary) (any, error) {
 
 	switch expr.Operator.Type {
 	case lexer.MINUS:
+		if result, handled, err := intr.unaryOverload(expr.Operator, right); handled {
+			return result, err
+		}
 		err := checkNumberOperand(expr.Operator, right)
 		if err != nil {
 			return nil, err
@@ -273,6 +566,23 @@ func (intr *Interpreter) VisitBinary(expr *ast.Binary) (any, error) {
 		return nil, rErr
 	}
 
+	// equality falls back to builtin rules when no operand defines __eq
+	if expr.Operator.Type == lexer.EQUAL_EQUAL || expr.Operator.Type == lexer.BANG_EQUAL {
+		equal, handled, err := intr.equalOverload(expr.Operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		if !handled {
+			equal = intr.isEqual(left, right)
+		}
+		return equal == (expr.Operator.Type == lexer.EQUAL_EQUAL), nil
+	}
+
+	// instance operands dispatch to their operator methods
+	if result, handled, err := intr.binaryOverload(expr.Operator, left, right); handled {
+		return result, err
+	}
+
 	switch expr.Operator.Type {
 	// arithmetic
 	case lexer.MINUS:
@@ -306,6 +616,9 @@ func (intr *Interpreter) VisitBinary(expr *ast.Binary) (any, error) {
 		lStr, isStrL := left.(string)
 		rStr, isStrR := right.(string)
 		if isStrL && isStrR {
+			if err := intr.allocate(expr.Operator, len(lStr)+len(rStr)); err != nil {
+				return nil, err
+			}
 			return lStr + rStr, nil
 		}
 		// type match failed, return error
@@ -339,12 +652,6 @@ func (intr *Interpreter) VisitBinary(expr *ast.Binary) (any, error) {
 			return nil, err
 		}
 		return left.(float64) <= right.(float64), nil
-
-	// equality
-	case lexer.EQUAL_EQUAL:
-		return intr.isEqual(left, right), nil
-	case lexer.BANG_EQUAL:
-		return !intr.isEqual(left, right), nil
 	}
 
 	// should be unreachable
@@ -367,23 +674,40 @@ func (intr *Interpreter) VisitCall(expr *ast.Call) (any, error) {
 		arguments = append(arguments, argEval)
 	}
 
+	return intr.call(expr.Paren, callee, arguments)
+}
+
+// call callee with evaluated arguments, token is used to report runtime errors at call site
+func (intr *Interpreter) call(token *lexer.Token, callee any, arguments []any) (any, error) {
 	function, ok := callee.(LoxCallable)
 	if !ok {
 		notCallableErr := &RuntimeError{
-			token:   expr.Paren,
+			token:   token,
 			message: "Can only call functions and classes.",
 		}
 		return nil, notCallableErr
 	}
 
-	if function.Arity() != len(arguments) {
+	if function.Arity() != VariadicArity && function.Arity() != len(arguments) {
 		arityErr := &RuntimeError{
-			token:   expr.Paren,
+			token:   token,
 			message: fmt.Sprintf("Expected %d arguments but got %d.", function.Arity(), len(arguments)),
 		}
 		return nil, arityErr
 	}
 
+	if intr.maxCallDepth > 0 && intr.frames.Len() >= intr.maxCallDepth {
+		return nil, &RuntimeError{
+			token:   token,
+			message: "Stack overflow.",
+		}
+	}
+
+	if err := intr.step(token); err != nil {
+		return nil, err
+	}
+
+	intr.callSite = token
 	return function.Call(intr, arguments)
 }
 
@@ -393,15 +717,75 @@ func (intr *Interpreter) VisitGet(expr *ast.Get) (any, error) {
 		return nil, objectErr
 	}
 
-	instance, isInstance := object.(*LoxInstance)
-	if !isInstance {
-		return &RuntimeError{
+	loxObject, isObject := object.(LoxObject)
+	if !isObject {
+		return nil, &RuntimeError{
 			token:   expr.Name,
 			message: "Only instances have properties.",
-		}, nil
+		}
+	}
+
+	return loxObject.Get(intr, expr.Name)
+}
+
+func (intr *Interpreter) VisitIndexGet(expr *ast.IndexGet) (any, error) {
+	object, objectErr := intr.evaluate(expr.Object)
+	if objectErr != nil {
+		return nil, objectErr
+	}
+
+	index, indexErr := intr.evaluate(expr.Index)
+	if indexErr != nil {
+		return nil, indexErr
 	}
 
-	return instance.Get(expr.Name)
+	indexable, isIndexable := object.(LoxIndexable)
+	if !isIndexable {
+		return nil, &RuntimeError{
+			token:   expr.Bracket,
+			message: "Only lists and maps can be indexed.",
+		}
+	}
+
+	return indexable.GetIndex(expr.Bracket, index)
+}
+
+func (intr *Interpreter) VisitIndexSet(expr *ast.IndexSet) (any, error) {
+	object, objectErr := intr.evaluate(expr.Object)
+	if objectErr != nil {
+		return nil, objectErr
+	}
+
+	index, indexErr := intr.evaluate(expr.Index)
+	if indexErr != nil {
+		return nil, indexErr
+	}
+
+	indexable, isIndexable := object.(LoxIndexable)
+	if !isIndexable {
+		return nil, &RuntimeError{
+			token:   expr.Bracket,
+			message: "Only lists and maps can be indexed.",
+		}
+	}
+
+	value, valueErr := intr.evaluate(expr.Value)
+	if valueErr != nil {
+		return nil, valueErr
+	}
+
+	// new map key grows map, overwriting list element or existing key doesn't
+	if m, isMap := object.(*LoxMap); isMap && !m.contains(index) {
+		if err := intr.allocate(expr.Bracket, entrySize); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := indexable.SetIndex(expr.Bracket, index, value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
 }
 
 func (intr *Interpreter) VisitAssign(expr *ast.Assign) (any, error) {
@@ -432,6 +816,11 @@ func (intr *Interpreter) VisitBlock(stmt *ast.Block) (any, error) {
 	return nil, err
 }
 
+func (intr *Interpreter) VisitBreak(stmt *ast.Break) (any, error) {
+	// unwind to enclosing VisitWhile, same as return value unwinds to LoxFunction.Call
+	return nil, &ThrownBreak{}
+}
+
 func (intr *Interpreter) VisitClass(stmt *ast.Class) (any, error) {
 	var superclass *LoxClass
 	if stmt.Superclass != nil {
@@ -449,6 +838,22 @@ func (intr *Interpreter) VisitClass(stmt *ast.Class) (any, error) {
 		superclass = loxSuperclass
 	}
 
+	traits := []*LoxTrait{}
+	for _, traitVar := range stmt.Traits {
+		value, err := intr.evaluate(traitVar)
+		if err != nil {
+			return nil, err
+		}
+		trait, isTrait := value.(*LoxTrait)
+		if !isTrait {
+			return nil, &RuntimeError{
+				token:   traitVar.Name,
+				message: "Can only use traits after 'with'.",
+			}
+		}
+		traits = append(traits, trait)
+	}
+
 	intr.environment.define(stmt.Name.Lexeme, nil)
 
 	if stmt.Superclass != nil {
@@ -461,34 +866,115 @@ func (intr *Interpreter) VisitClass(stmt *ast.Class) (any, error) {
 		function := &LoxFunction{
 			declaration:   method,
 			closure:       intr.environment,
+			module:        intr.module,
 			isInitializer: method.Name.Lexeme == "init", // check if method is constructor
 		}
 		methods[method.Name.Lexeme] = function
 	}
 
+	// trait methods sit between class's own methods and superclass in lookup order
+	if err := mixTraits(stmt, traits, methods); err != nil {
+		if stmt.Superclass != nil {
+			intr.environment = intr.environment.enclosing
+		}
+		return nil, err
+	}
+
+	classMethods := intr.methodTable(stmt.ClassMethods)
+	getters := intr.methodTable(stmt.Getters)
+	setters := intr.methodTable(stmt.Setters)
+
 	if stmt.Superclass != nil {
 		intr.environment = intr.environment.enclosing
 	}
 
+	// class methods live on metaclass, which inherits from metaclass of superclass
+	var superMetaclass *LoxClass
+	if superclass != nil {
+		superMetaclass = superclass.metaclass
+	}
+	metaclass := &LoxClass{
+		name:       stmt.Name.Lexeme + " metaclass",
+		superclass: superMetaclass,
+		methods:    classMethods,
+		fields:     make(map[string]any),
+	}
+
+	// class, its metaclass and every method function
+	memberCount := len(methods) + len(classMethods) + len(getters) + len(setters)
+	if err := intr.allocate(stmt.Name, (2+memberCount)*objectSize); err != nil {
+		return nil, err
+	}
+
 	class := &LoxClass{
 		name:       stmt.Name.Lexeme,
 		superclass: superclass,
 		methods:    methods,
+		getters:    getters,
+		setters:    setters,
+		metaclass:  metaclass,
+		fields:     make(map[string]any),
 	}
 	intr.environment.assign(stmt.Name, class)
 
 	return nil, nil
 }
 
+// create functions for class members other than instance methods, closing over current environment
+func (intr *Interpreter) methodTable(declarations []*ast.Function) map[string]*LoxFunction {
+	table := make(map[string]*LoxFunction)
+	for _, declaration := range declarations {
+		table[declaration.Name.Lexeme] = &LoxFunction{
+			declaration:   declaration,
+			closure:       intr.environment,
+			module:        intr.module,
+			isInitializer: false,
+		}
+	}
+	return table
+}
+
+func (intr *Interpreter) VisitContinue(stmt *ast.Continue) (any, error) {
+	return nil, &ThrownContinue{}
+}
+
+func (intr *Interpreter) VisitExport(stmt *ast.Export) (any, error) {
+	if _, err := intr.execute(stmt.Declaration); err != nil {
+		return nil, err
+	}
+
+	intr.module.exports[declarationName(stmt.Declaration).Lexeme] = true
+	return nil, nil
+}
+
+// name token of declaration statement which can be exported
+func declarationName(stmt ast.Stmt) *lexer.Token {
+	switch declaration := stmt.(type) {
+	case *ast.Var:
+		return declaration.Name
+	case *ast.Function:
+		return declaration.Name
+	case *ast.Class:
+		return declaration.Name
+	case *ast.Trait:
+		return declaration.Name
+	}
+	panic(fmt.Sprintf("Statement can't be exported: %T", stmt))
+}
+
 func (intr *Interpreter) VisitExpression(stmt *ast.Expression) (any, error) {
 	_, err := intr.evaluate(stmt.Expression)
 	return nil, err
 }
 
 func (intr *Interpreter) VisitFunction(stmt *ast.Function) (any, error) {
+	if err := intr.allocate(stmt.Name, objectSize); err != nil {
+		return nil, err
+	}
 	function := &LoxFunction{
 		declaration:   stmt,
 		closure:       intr.environment,
+		module:        intr.module,
 		isInitializer: false,
 	}
 	intr.environment.define(stmt.Name.Lexeme, function)
@@ -511,12 +997,38 @@ func (intr *Interpreter) VisitIf(stmt *ast.If) (any, error) {
 	return nil, execErr
 }
 
+func (intr *Interpreter) VisitImport(stmt *ast.Import) (any, error) {
+	module, err := intr.importModule(stmt.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.Alias != nil {
+		intr.environment.define(stmt.Alias.Lexeme, module)
+		return nil, nil
+	}
+
+	for _, name := range stmt.Names {
+		value, getErr := module.Get(intr, name)
+		if getErr != nil {
+			return nil, getErr
+		}
+		intr.environment.define(name.Lexeme, value)
+	}
+
+	return nil, nil
+}
+
 func (intr *Interpreter) VisitPrint(stmt *ast.Print) (any, error) {
 	val, err := intr.evaluate(stmt.Expression)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("%s\n", PrintEvaluation(val))
+	str, err := intr.stringify(stmt.Keyword, val)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(intr.stdout, "%s\n", str)
 	return nil, nil
 }
 
@@ -535,6 +1047,70 @@ func (intr *Interpreter) VisitReturn(stmt *ast.Return) (any, error) {
 	return nil, &ThrownReturn{value: value}
 }
 
+func (intr *Interpreter) VisitTrait(stmt *ast.Trait) (any, error) {
+	if err := intr.allocate(stmt.Name, (1+len(stmt.Methods))*objectSize); err != nil {
+		return nil, err
+	}
+	trait := &LoxTrait{
+		name:    stmt.Name.Lexeme,
+		methods: intr.methodTable(stmt.Methods),
+	}
+	intr.environment.define(stmt.Name.Lexeme, trait)
+
+	return nil, nil
+}
+
+func (intr *Interpreter) VisitThrow(stmt *ast.Throw) (any, error) {
+	value, err := intr.evaluate(stmt.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// uncaught exception is reported after script stops, so __str of thrown value runs while it still can
+	message, err := intr.stringify(stmt.Keyword, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, &ThrownError{token: stmt.Keyword, value: value, message: message}
+}
+
+func (intr *Interpreter) VisitTry(stmt *ast.Try) (any, error) {
+	err := intr.executeBlock(stmt.Body, NewEnvironment(intr.environment))
+
+	if err != nil && stmt.CatchName != nil {
+		// return, break and continue pass through try, only errors are caught
+		if caught, isCatchable := catchValue(err); isCatchable {
+			environment := NewEnvironment(intr.environment)
+			environment.define(stmt.CatchName.Lexeme, caught)
+			err = intr.executeBlock(stmt.CatchBody, environment)
+		}
+	}
+
+	if stmt.FinallyBody != nil {
+		// finally always runs, its own error or return replaces pending one
+		if finallyErr := intr.executeBlock(stmt.FinallyBody, NewEnvironment(intr.environment)); finallyErr != nil {
+			return nil, finallyErr
+		}
+	}
+
+	return nil, err
+}
+
+// convert error to value visible to catch block
+// errors raised inside other module are caught the same way
+func catchValue(err error) (any, bool) {
+	var thrownErr *ThrownError
+	if errors.As(err, &thrownErr) {
+		return thrownErr.value, true
+	}
+	var runtimeErr *RuntimeError
+	if errors.As(err, &runtimeErr) {
+		return &LoxError{message: runtimeErr.message, line: runtimeErr.token.Line}, true
+	}
+	return nil, false
+}
+
 func (intr *Interpreter) VisitVar(stmt *ast.Var) (any, error) {
 	var value any
 	var err error
@@ -542,6 +1118,9 @@ func (intr *Interpreter) VisitVar(stmt *ast.Var) (any, error) {
 	if stmt.Initializer != nil {
 		value, err = intr.evaluate(stmt.Initializer)
 	}
+	if err == nil {
+		err = intr.allocate(stmt.Name, entrySize)
+	}
 
 	intr.environment.define(stmt.Name.Lexeme, value)
 	return nil, err
@@ -554,9 +1133,25 @@ func (intr *Interpreter) VisitWhile(stmt *ast.While) (any, error) {
 	}
 
 	for intr.isTruthy(cond) {
+		if err := intr.step(stmt.Keyword); err != nil {
+			return nil, err
+		}
+
 		_, err := intr.execute(stmt.Body)
 		if err != nil {
-			return nil, err
+			if _, isBreak := err.(*ThrownBreak); isBreak {
+				break
+			}
+			// continue only skips rest of the body, increment of desugared for loop still runs
+			if _, isContinue := err.(*ThrownContinue); !isContinue {
+				return nil, err
+			}
+		}
+
+		if stmt.Increment != nil {
+			if _, incErr := intr.evaluate(stmt.Increment); incErr != nil {
+				return nil, incErr
+			}
 		}
 
 		cond, condErr = intr.evaluate(stmt.Condition)
@@ -0,0 +1,274 @@
+package interpreter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dydev10/glox/interpreter"
+	"github.com/dydev10/glox/lexer"
+	"github.com/dydev10/glox/parser"
+)
+
+type order struct {
+	ID    int
+	Total float64
+}
+
+func TestGlobalsAndCall(t *testing.T) {
+	intr, stdout := newTestInterpreter()
+	if err := intr.SetGlobal("limit", 100); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := run(t, intr, stdout, `
+var seen = 0;
+fun evaluate(order) {
+  seen = seen + 1;
+  if (order.Total > limit) return "reject";
+  return "accept";
+}
+class Point { init(x) { this.x = x; } }
+fun fail() { return 1 + nil; }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evaluate, ok := intr.GetGlobal("evaluate")
+	if !ok {
+		t.Fatal("evaluate is not defined")
+	}
+	for _, tc := range []struct {
+		order *order
+		want  string
+	}{
+		{&order{ID: 1, Total: 50}, "accept"},
+		{&order{ID: 2, Total: 500}, "reject"},
+	} {
+		result, err := intr.Call(context.Background(), evaluate, tc.order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != tc.want {
+			t.Errorf("evaluate(%+v) = %v, want %s", tc.order, result, tc.want)
+		}
+	}
+
+	if seen, _ := intr.GetGlobal("seen"); seen != 2.0 {
+		t.Errorf("seen = %v, want 2", seen)
+	}
+
+	point, _ := intr.GetGlobal("Point")
+	instance, err := intr.Call(context.Background(), point, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := instance.(interface{ String() string }).String(); got != "Point instance" {
+		t.Errorf("Point(3) = %s", got)
+	}
+
+	if _, err := intr.Call(context.Background(), evaluate); err == nil {
+		t.Error("call with wrong arity should fail")
+	}
+	if _, err := intr.Call(context.Background(), "evaluate"); err == nil {
+		t.Error("calling a string should fail")
+	}
+	fail, _ := intr.GetGlobal("fail")
+	if _, err := intr.Call(context.Background(), fail); err == nil {
+		t.Error("runtime error inside called function should be returned")
+	}
+
+	if _, ok := intr.GetGlobal("missing"); ok {
+		t.Error("missing global should not be found")
+	}
+	if _, ok := intr.GetGlobal("clock"); !ok {
+		t.Error("builtins should be visible as globals")
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	for _, tc := range []struct {
+		source string
+		fails  bool
+	}{
+		{`for (var i = 0; i < 100000; i = i + 1) { var t = i; }`, false},
+		{`for (var i = 0; i < 20000; i = i + 1) { var p = [i, i]; }`, false},
+		{`var last; for (var i = 0; i < 20000; i = i + 1) { last = {"s": "item ${i}", "f": fun () { return i; }}; }`, false},
+		{`class P { init(x) { this.x = x; } } for (var i = 0; i < 20000; i = i + 1) { var p = P(i); p.x = [p]; }`, false},
+		{`fun f(n) { var x = n; return x; } for (var i = 0; i < 100000; i = i + 1) f(i);`, false},
+		{`var fs = []; for (var i = 0; i < 100000; i = i + 1) { var t = i; fs.push(fun () { return t; }); }`, true},
+		{`var s = "ab"; while (true) s = s + s;`, true},
+		{`var l = []; while (true) l.push(1);`, true},
+	} {
+		intr, stdout := newTestInterpreter(interpreter.WithMemoryLimit(1 << 20))
+		_, err := run(t, intr, stdout, tc.source)
+		var limitErr *interpreter.MemoryLimitError
+		if failed := errors.As(err, &limitErr); failed != tc.fails {
+			t.Errorf("%s: err = %v, want memory limit error %v", tc.source, err, tc.fails)
+		}
+	}
+}
+
+func TestModuleErrorNamesModule(t *testing.T) {
+	dir := t.TempDir()
+	module := filepath.Join(dir, "m.lox")
+	if err := os.WriteFile(module, []byte("export fun boom() { return 1 + nil; }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	intr, stdout := newTestInterpreter(interpreter.WithScriptPath(filepath.Join(dir, "main.lox")))
+	out, err := run(t, intr, stdout, `
+import "m.lox" as m;
+try { m.boom(); } catch (e) { print e.message; }
+m.boom();
+`)
+	if out != "Operands must be two numbers or two strings.\n" {
+		t.Errorf("output = %q, error from module should be catchable", out)
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), module+": [line 1]") {
+		t.Errorf("err = %v, want error naming %s", err, module)
+	}
+
+	broken := filepath.Join(dir, "broken.lox")
+	if err := os.WriteFile(broken, []byte("export var x = ;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = run(t, intr, stdout, `import "broken.lox" as b;`)
+	if err == nil || !strings.Contains(err.Error(), "\n"+broken+": [line 1] Error: Expect expression.") {
+		t.Errorf("err = %v, want load error naming %s", err, broken)
+	}
+}
+
+func TestSuperGetterAndSetter(t *testing.T) {
+	intr, stdout := newTestInterpreter()
+	out, err := run(t, intr, stdout, `
+class A {
+  init() { this.w = 2; }
+  area { return this.w * 3; }
+  set width(w) { this.w = w; }
+}
+class B < A {
+  area { return super.area + 1; }
+  set width(w) { super.width = w * 10; }
+}
+var b = B();
+print b.area;
+b.width = 1;
+print b.w;
+print b.area;
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "7\n10\n31\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+
+	_, err = run(t, intr, stdout, `class C < A { assign() { super.missing = 1; } } C().assign();`)
+	if err == nil || !strings.Contains(err.Error(), "Undefined setter 'missing'") {
+		t.Errorf("err = %v, want undefined setter error", err)
+	}
+}
+
+func TestStrMethodInErrors(t *testing.T) {
+	intr, stdout := newTestInterpreter()
+	_, err := run(t, intr, stdout, `
+class V {
+  init(n) { this.n = n; }
+  __str() { return "V(${this.n})"; }
+}
+throw V(1);
+`)
+	if err == nil || !strings.HasSuffix(err.Error(), "Uncaught exception: V(1)") {
+		t.Errorf("err = %v, want uncaught exception formatted with __str", err)
+	}
+
+	_, err = run(t, intr, stdout, `
+class Bad {
+  __str() { return nil + 1; }
+}
+
+print Bad();
+`)
+	var traced interpreter.Traced
+	if !errors.As(err, &traced) || !strings.Contains(traced.Traceback(), "[line 6] in script") {
+		t.Errorf("err = %v, want traceback through print at line 6", err)
+	}
+}
+
+func TestNativeErrors(t *testing.T) {
+	intr, stdout := newTestInterpreter(interpreter.WithMemoryLimit(1 << 16))
+	err := intr.DefineNative("callback", 1, func(args []interpreter.Value) (interpreter.Value, error) {
+		if _, err := intr.Call(context.Background(), args[0]); err != nil {
+			return nil, fmt.Errorf("callback failed: %w", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run(t, intr, stdout, `
+try {
+  callback(fun () { var l = []; while (true) l.push(1); });
+} catch (e) {
+  print "caught";
+}
+`)
+	var limitErr *interpreter.MemoryLimitError
+	if out != "" || !errors.As(err, &limitErr) {
+		t.Errorf("output = %q, err = %v, wrapped limit error should not be catchable", out, err)
+	}
+
+	if err := intr.DefineNative("bad", -2, nil); err == nil {
+		t.Error("negative arity other than VariadicArity should be rejected")
+	}
+}
+
+func TestNestedCallKeepsContext(t *testing.T) {
+	intr, _ := newTestInterpreter()
+	err := intr.DefineNative("callback", 1, func(args []interpreter.Value) (interpreter.Value, error) {
+		// host passes fresh context, running script's timeout must still apply
+		return intr.Call(context.Background(), args[0])
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := lexer.New(`callback(fun () { while (true) {} });`)
+	statements, _ := parser.NewParser(l.Lex()).Parse()
+	interpreter.NewResolver(intr).Resolve(statements)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = intr.Interpret(ctx, statements)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want script timeout", err)
+	}
+}
+
+func TestArrowFunctionBodies(t *testing.T) {
+	intr, stdout := newTestInterpreter()
+	out, err := run(t, intr, stdout, `
+var double = (x) => { return x * 2; };
+var empty = () => {};
+var entry = () => ({"a": 1});
+var add = (a, b) => a + b;
+print double(4);
+print empty();
+print entry();
+print add(1, 2);
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "8\nnil\n{a: 1}\n3\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
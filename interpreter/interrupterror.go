@@ -0,0 +1,65 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// ErrStepBudgetExhausted is cause of InterruptError when script runs more steps than its budget allows
+var ErrStepBudgetExhausted = errors.New("step budget exhausted")
+
+// InterruptError stops script from outside, lox catch blocks can't handle it.
+// cause is context error or ErrStepBudgetExhausted, so errors.Is tells them apart
+type InterruptError struct {
+	token *lexer.Token
+	cause error
+}
+
+func (ie *InterruptError) Error() string {
+	return fmt.Sprintf("[line %d] Error: %s", ie.token.Line, ie.message())
+}
+
+func (ie *InterruptError) message() string {
+	switch {
+	case errors.Is(ie.cause, context.DeadlineExceeded):
+		return "Execution timed out."
+	case errors.Is(ie.cause, ErrStepBudgetExhausted):
+		return "Step budget exhausted."
+	}
+	return "Execution cancelled."
+}
+
+func (ie *InterruptError) Unwrap() error {
+	return ie.cause
+}
+
+func (ie *InterruptError) Span() lexer.Span {
+	return ie.token.Span()
+}
+
+// count one step of loop iteration or call, fails once context is done or step budget runs out
+func (intr *Interpreter) step(token *lexer.Token) error {
+	if intr.ctx.Err() != nil {
+		return &InterruptError{token: token, cause: context.Cause(intr.ctx)}
+	}
+
+	intr.steps++
+	if intr.stepBudget > 0 && intr.steps > intr.stepBudget {
+		return &InterruptError{token: token, cause: ErrStepBudgetExhausted}
+	}
+
+	return nil
+}
+
+// context done when either outer or inner one is done, cause of outer is kept so timeouts are reported as such
+func mergeContext(outer, inner context.Context) (context.Context, context.CancelFunc) {
+	merged, cancel := context.WithCancelCause(inner)
+	stop := context.AfterFunc(outer, func() { cancel(context.Cause(outer)) })
+	return merged, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
@@ -1,9 +1,20 @@
 package interpreter
 
+import (
+	"fmt"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// LoxClass is also an object, it is instance of its metaclass which holds class methods
 type LoxClass struct {
 	name       string
 	superclass *LoxClass
 	methods    map[string]*LoxFunction
+	getters    map[string]*LoxFunction
+	setters    map[string]*LoxFunction
+	metaclass  *LoxClass
+	fields     map[string]any
 }
 
 func (c *LoxClass) Arity() int {
@@ -15,6 +26,9 @@ func (c *LoxClass) Arity() int {
 }
 
 func (c *LoxClass) Call(intr *Interpreter, arguments []any) (any, error) {
+	if err := intr.allocate(intr.callSite, objectSize); err != nil {
+		return nil, err
+	}
 	instance := &LoxInstance{
 		class:  c,
 		fields: make(map[string]any),
@@ -22,12 +36,44 @@ func (c *LoxClass) Call(intr *Interpreter, arguments []any) (any, error) {
 	// call constructor method of class after binding 'this'
 	initializer := c.FindMethod("init")
 	if initializer != nil {
-		initializer.Bind(instance).Call(intr, arguments)
+		// initializer runs in frame named after class, matching how it was called
+		intr.enterFrame(c.name)
+		_, err := initializer.Bind(instance).invoke(intr, arguments)
+		if err = intr.leaveFrame(err); err != nil {
+			return nil, err
+		}
 	}
 
 	return instance, nil
 }
 
+func (c *LoxClass) Get(intr *Interpreter, name *lexer.Token) (any, error) {
+	if field, ok := c.fields[name.Lexeme]; ok {
+		return field, nil
+	}
+
+	if c.metaclass != nil {
+		if method := c.metaclass.FindMethod(name.Lexeme); method != nil {
+			return intr.bindMethod(name, method, c)
+		}
+	}
+
+	return nil, &RuntimeError{
+		token:   name,
+		message: fmt.Sprintf("Undefined property '%s'.", name.Lexeme),
+	}
+}
+
+func (c *LoxClass) Set(intr *Interpreter, name *lexer.Token, value any) error {
+	if _, exists := c.fields[name.Lexeme]; !exists {
+		if err := intr.allocate(name, entrySize); err != nil {
+			return err
+		}
+	}
+	c.fields[name.Lexeme] = value
+	return nil
+}
+
 func (c *LoxClass) String() string {
 	return c.name
 }
@@ -43,3 +89,27 @@ func (c *LoxClass) FindMethod(name string) *LoxFunction {
 
 	return nil
 }
+
+func (c *LoxClass) FindGetter(name string) *LoxFunction {
+	if getter, ok := c.getters[name]; ok {
+		return getter
+	}
+
+	if c.superclass != nil {
+		return c.superclass.FindGetter(name)
+	}
+
+	return nil
+}
+
+func (c *LoxClass) FindSetter(name string) *LoxFunction {
+	if setter, ok := c.setters[name]; ok {
+		return setter
+	}
+
+	if c.superclass != nil {
+		return c.superclass.FindSetter(name)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// LoxError is the value bound to catch variable when builtin RuntimeError is caught
+type LoxError struct {
+	message string
+	line    int
+}
+
+func (e *LoxError) Get(intr *Interpreter, name *lexer.Token) (any, error) {
+	switch name.Lexeme {
+	case "message":
+		return e.message, nil
+	case "line":
+		return float64(e.line), nil
+	}
+
+	return nil, &RuntimeError{
+		token:   name,
+		message: fmt.Sprintf("Undefined property '%s'.", name.Lexeme),
+	}
+}
+
+func (e *LoxError) String() string {
+	return fmt.Sprintf("<error: %s>", e.message)
+}
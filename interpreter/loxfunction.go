@@ -1,10 +1,14 @@
 package interpreter
 
-import "github.com/dydev10/glox/ast"
+import (
+	"github.com/dydev10/glox/ast"
+	"github.com/dydev10/glox/lexer"
+)
 
 type LoxFunction struct {
 	declaration   *ast.Function
 	closure       *Environment
+	module        *LoxModule // module where function is declared, its globals are used while function runs
 	isInitializer bool
 }
 
@@ -13,6 +17,20 @@ func (f *LoxFunction) Arity() int {
 }
 
 func (f *LoxFunction) Call(intr *Interpreter, arguments []any) (any, error) {
+	intr.enterFrame(f.frameName())
+	result, err := f.invoke(intr, arguments)
+	return result, intr.leaveFrame(err)
+}
+
+// run function body without pushing call frame, caller owns the frame
+func (f *LoxFunction) invoke(intr *Interpreter, arguments []any) (any, error) {
+	if err := intr.allocate(intr.callSite, environmentSize+len(arguments)*entrySize); err != nil {
+		return nil, err
+	}
+
+	prevModule := intr.enterModule(f.module)
+	defer intr.enterModule(prevModule)
+
 	environment := NewEnvironment(f.closure)
 
 	for i := range f.declaration.Params {
@@ -24,32 +42,57 @@ func (f *LoxFunction) Call(intr *Interpreter, arguments []any) (any, error) {
 		if isReturn {
 			// if its a constructor, ignore return value and just return 'this'. return value syntax should be block by resolver
 			if f.isInitializer {
-				return f.closure.getAt(0, "this")
+				return f.closure.getAt(0, thisToken(f.declaration.Name))
 			}
 			return thrownReturn.value, nil
 		}
+		// function of other module reports errors against its own file
+		if f.module != prevModule {
+			err = moduleError(f.module, err)
+		}
 		return nil, err
 	}
 
 	// always return 'this' if its constructor
 	if f.isInitializer {
-		return f.closure.getAt(0, "this")
+		return f.closure.getAt(0, thisToken(f.declaration.Name))
 	}
 
 	return nil, nil
 }
 
+func (f *LoxFunction) frameName() string {
+	if f.declaration.Name == nil {
+		return "anonymous"
+	}
+	return f.declaration.Name.Lexeme
+}
+
 func (f *LoxFunction) String() string {
+	// lambda expressions don't have name token
+	if f.declaration.Name == nil {
+		return "<fn anonymous>"
+	}
 	return "<fn " + f.declaration.Name.Lexeme + ">"
 }
 
-func (f *LoxFunction) Bind(instance *LoxInstance) *LoxFunction {
+// bound method handed out to script, charged for environment holding 'this' and new function
+func (intr *Interpreter) bindMethod(token *lexer.Token, method *LoxFunction, this any) (*LoxFunction, error) {
+	if err := intr.allocate(token, environmentSize+entrySize+objectSize); err != nil {
+		return nil, err
+	}
+	return method.Bind(this), nil
+}
+
+// bind 'this' to instance, or to class object for class methods
+func (f *LoxFunction) Bind(this any) *LoxFunction {
 	environment := NewEnvironment(f.closure)
-	environment.define("this", instance)
+	environment.define("this", this)
 
 	return &LoxFunction{
 		declaration:   f.declaration,
 		closure:       environment,
+		module:        f.module,
 		isInitializer: f.isInitializer,
 	}
 }
@@ -11,13 +11,18 @@ type LoxInstance struct {
 	fields map[string]any
 }
 
-func (i *LoxInstance) Get(name *lexer.Token) (any, error) {
+func (i *LoxInstance) Get(intr *Interpreter, name *lexer.Token) (any, error) {
+	// getter runs on property access, it takes precedence over field with same name
+	if getter := i.class.FindGetter(name.Lexeme); getter != nil {
+		return intr.call(name, getter.Bind(i), []any{})
+	}
+
 	if field, ok := i.fields[name.Lexeme]; ok {
 		return field, nil
 	}
 
 	if method := i.class.FindMethod(name.Lexeme); method != nil {
-		return method.Bind(i), nil
+		return intr.bindMethod(name, method, i)
 	}
 
 	return nil, &RuntimeError{
@@ -26,8 +31,28 @@ func (i *LoxInstance) Get(name *lexer.Token) (any, error) {
 	}
 }
 
-func (i *LoxInstance) Set(name *lexer.Token, value any) {
+func (i *LoxInstance) Set(intr *Interpreter, name *lexer.Token, value any) error {
+	if setter := i.class.FindSetter(name.Lexeme); setter != nil {
+		_, err := intr.call(name, setter.Bind(i), []any{value})
+		return err
+	}
+
+	// property with only getter is read-only, assigning field would be hidden by getter anyway
+	if i.class.FindGetter(name.Lexeme) != nil {
+		return &RuntimeError{
+			token:   name,
+			message: fmt.Sprintf("Can't assign to read-only property '%s'.", name.Lexeme),
+		}
+	}
+
+	if _, exists := i.fields[name.Lexeme]; !exists {
+		if err := intr.allocate(name, entrySize); err != nil {
+			return err
+		}
+	}
+
 	i.fields[name.Lexeme] = value
+	return nil
 }
 
 func (i *LoxInstance) String() string {
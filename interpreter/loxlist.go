@@ -0,0 +1,161 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+type LoxList struct {
+	elements []any
+}
+
+type listMethod struct {
+	arity int
+	fn    func(intr *Interpreter, list *LoxList, name *lexer.Token, arguments []any) (any, error)
+}
+
+var listMethods = map[string]listMethod{
+	"push": {1, func(intr *Interpreter, list *LoxList, name *lexer.Token, arguments []any) (any, error) {
+		if err := intr.allocate(name, valueSize); err != nil {
+			return nil, err
+		}
+		list.elements = append(list.elements, arguments[0])
+		return nil, nil
+	}},
+	"pop": {0, func(intr *Interpreter, list *LoxList, name *lexer.Token, arguments []any) (any, error) {
+		if len(list.elements) == 0 {
+			return nil, &RuntimeError{token: name, message: "Can't pop from an empty list."}
+		}
+		last := list.elements[len(list.elements)-1]
+		list.elements = list.elements[:len(list.elements)-1]
+		return last, nil
+	}},
+	"len": {0, func(intr *Interpreter, list *LoxList, name *lexer.Token, arguments []any) (any, error) {
+		return float64(len(list.elements)), nil
+	}},
+	"slice": {2, func(intr *Interpreter, list *LoxList, name *lexer.Token, arguments []any) (any, error) {
+		start, startErr := integerIndex(name, arguments[0])
+		if startErr != nil {
+			return nil, startErr
+		}
+		end, endErr := integerIndex(name, arguments[1])
+		if endErr != nil {
+			return nil, endErr
+		}
+		if start < 0 || end > len(list.elements) || start > end {
+			return nil, &RuntimeError{token: name, message: "Slice bounds out of range."}
+		}
+		if err := intr.allocate(name, objectSize+(end-start)*valueSize); err != nil {
+			return nil, err
+		}
+		// copy elements so that new list doesn't share backing array
+		return &LoxList{elements: append([]any{}, list.elements[start:end]...)}, nil
+	}},
+	"map": {1, func(intr *Interpreter, list *LoxList, name *lexer.Token, arguments []any) (any, error) {
+		if err := intr.allocate(name, objectSize+len(list.elements)*valueSize); err != nil {
+			return nil, err
+		}
+		mapped := make([]any, 0, len(list.elements))
+		for _, element := range list.elements {
+			value, err := intr.call(name, arguments[0], []any{element})
+			if err != nil {
+				return nil, err
+			}
+			mapped = append(mapped, value)
+		}
+		return &LoxList{elements: mapped}, nil
+	}},
+	"filter": {1, func(intr *Interpreter, list *LoxList, name *lexer.Token, arguments []any) (any, error) {
+		// filtered list is at most as long as original
+		if err := intr.allocate(name, objectSize+len(list.elements)*valueSize); err != nil {
+			return nil, err
+		}
+		filtered := []any{}
+		for _, element := range list.elements {
+			keep, err := intr.call(name, arguments[0], []any{element})
+			if err != nil {
+				return nil, err
+			}
+			if intr.isTruthy(keep) {
+				filtered = append(filtered, element)
+			}
+		}
+		return &LoxList{elements: filtered}, nil
+	}},
+	"reduce": {2, func(intr *Interpreter, list *LoxList, name *lexer.Token, arguments []any) (any, error) {
+		accumulator := arguments[1]
+		for _, element := range list.elements {
+			value, err := intr.call(name, arguments[0], []any{accumulator, element})
+			if err != nil {
+				return nil, err
+			}
+			accumulator = value
+		}
+		return accumulator, nil
+	}},
+}
+
+func (l *LoxList) Get(intr *Interpreter, name *lexer.Token) (any, error) {
+	method, ok := listMethods[name.Lexeme]
+	if !ok {
+		return nil, &RuntimeError{
+			token:   name,
+			message: fmt.Sprintf("Undefined property '%s'.", name.Lexeme),
+		}
+	}
+
+	// bind method to this list, name token is used to report errors from inside native method
+	return &NativeFunction{
+		name:  name.Lexeme,
+		arity: method.arity,
+		fn: func(intr *Interpreter, arguments []any) (any, error) {
+			return method.fn(intr, l, name, arguments)
+		},
+	}, nil
+}
+
+func (l *LoxList) GetIndex(bracket *lexer.Token, index any) (any, error) {
+	i, err := l.checkIndex(bracket, index)
+	if err != nil {
+		return nil, err
+	}
+	return l.elements[i], nil
+}
+
+func (l *LoxList) SetIndex(bracket *lexer.Token, index any, value any) error {
+	i, err := l.checkIndex(bracket, index)
+	if err != nil {
+		return err
+	}
+	l.elements[i] = value
+	return nil
+}
+
+func (l *LoxList) checkIndex(bracket *lexer.Token, index any) (int, error) {
+	i, err := integerIndex(bracket, index)
+	if err != nil {
+		return 0, err
+	}
+	if i < 0 || i >= len(l.elements) {
+		return 0, &RuntimeError{
+			token:   bracket,
+			message: fmt.Sprintf("List index %d out of range for length %d.", i, len(l.elements)),
+		}
+	}
+	return i, nil
+}
+
+func (l *LoxList) String() string {
+	return PrintEvaluation(l)
+}
+
+// convert number value to int index, only whole numbers are valid indexes
+func integerIndex(token *lexer.Token, index any) (int, error) {
+	num, isNum := index.(float64)
+	if !isNum || num != math.Trunc(num) || math.IsInf(num, 0) {
+		return 0, &RuntimeError{token: token, message: "Index must be an integer."}
+	}
+	return int(num), nil
+}
@@ -0,0 +1,163 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// LoxMap keeps entries in insertion order, so that iteration and printing are deterministic
+type LoxMap struct {
+	keys    []any
+	entries map[any]any
+}
+
+func NewLoxMap() *LoxMap {
+	return &LoxMap{
+		keys:    []any{},
+		entries: make(map[any]any),
+	}
+}
+
+type mapMethod struct {
+	arity int
+	fn    func(intr *Interpreter, m *LoxMap, name *lexer.Token, arguments []any) (any, error)
+}
+
+var mapMethods = map[string]mapMethod{
+	"keys": {0, func(intr *Interpreter, m *LoxMap, name *lexer.Token, arguments []any) (any, error) {
+		if err := intr.allocate(name, objectSize+len(m.keys)*valueSize); err != nil {
+			return nil, err
+		}
+		return &LoxList{elements: append([]any{}, m.keys...)}, nil
+	}},
+	"values": {0, func(intr *Interpreter, m *LoxMap, name *lexer.Token, arguments []any) (any, error) {
+		if err := intr.allocate(name, objectSize+len(m.keys)*valueSize); err != nil {
+			return nil, err
+		}
+		values := make([]any, 0, len(m.keys))
+		for _, key := range m.keys {
+			values = append(values, m.entries[key])
+		}
+		return &LoxList{elements: values}, nil
+	}},
+	"has": {1, func(intr *Interpreter, m *LoxMap, name *lexer.Token, arguments []any) (any, error) {
+		key, err := mapKey(name, arguments[0])
+		if err != nil {
+			return nil, err
+		}
+		_, ok := m.entries[key]
+		return ok, nil
+	}},
+	"delete": {1, func(intr *Interpreter, m *LoxMap, name *lexer.Token, arguments []any) (any, error) {
+		key, err := mapKey(name, arguments[0])
+		if err != nil {
+			return nil, err
+		}
+		return m.delete(key), nil
+	}},
+	"len": {0, func(intr *Interpreter, m *LoxMap, name *lexer.Token, arguments []any) (any, error) {
+		return float64(len(m.keys)), nil
+	}},
+}
+
+func (m *LoxMap) Get(intr *Interpreter, name *lexer.Token) (any, error) {
+	method, ok := mapMethods[name.Lexeme]
+	if !ok {
+		return nil, &RuntimeError{
+			token:   name,
+			message: fmt.Sprintf("Undefined property '%s'.", name.Lexeme),
+		}
+	}
+
+	// bind method to this map, name token is used to report errors from inside native method
+	return &NativeFunction{
+		name:  name.Lexeme,
+		arity: method.arity,
+		fn: func(intr *Interpreter, arguments []any) (any, error) {
+			return method.fn(intr, m, name, arguments)
+		},
+	}, nil
+}
+
+func (m *LoxMap) GetIndex(bracket *lexer.Token, index any) (any, error) {
+	key, err := mapKey(bracket, index)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := m.entries[key]
+	if !ok {
+		return nil, &RuntimeError{
+			token:   bracket,
+			message: fmt.Sprintf("Undefined key '%s'.", PrintEvaluation(index)),
+		}
+	}
+	return value, nil
+}
+
+func (m *LoxMap) SetIndex(bracket *lexer.Token, index any, value any) error {
+	key, err := mapKey(bracket, index)
+	if err != nil {
+		return err
+	}
+
+	m.set(key, value)
+	return nil
+}
+
+// report if index is already a key, invalid keys are never present
+func (m *LoxMap) contains(index any) bool {
+	key, err := mapKey(nil, index)
+	if err != nil {
+		return false
+	}
+	_, ok := m.entries[key]
+	return ok
+}
+
+func (m *LoxMap) set(key any, value any) {
+	if _, ok := m.entries[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.entries[key] = value
+}
+
+// remove key and report if it was present
+func (m *LoxMap) delete(key any) bool {
+	if _, ok := m.entries[key]; !ok {
+		return false
+	}
+
+	delete(m.entries, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (m *LoxMap) String() string {
+	return PrintEvaluation(m)
+}
+
+// validate map key, go map equality on these types matches Interpreter.isEqual
+func mapKey(token *lexer.Token, key any) (any, error) {
+	switch k := key.(type) {
+	case float64:
+		// -0 and 0 are equal numbers, store both under same key
+		if k == 0 {
+			return float64(0), nil
+		}
+		return k, nil
+	case string, bool, nil:
+		return k, nil
+	default:
+		return nil, &RuntimeError{
+			token:   token,
+			message: "Map key must be a string, number, boolean or nil.",
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package interpreter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dydev10/glox/ast"
+	"github.com/dydev10/glox/lexer"
+	"github.com/dydev10/glox/parser"
+)
+
+// LoxModule is a loaded script file, its top level names live in globals and only exported ones are visible to importers
+type LoxModule struct {
+	name    string
+	path    string
+	globals *Environment
+	exports map[string]bool
+}
+
+func newLoxModule(name, path string, builtins *Environment) *LoxModule {
+	return &LoxModule{
+		name:    name,
+		path:    path,
+		globals: NewEnvironment(builtins),
+		exports: make(map[string]bool),
+	}
+}
+
+func (m *LoxModule) Get(intr *Interpreter, name *lexer.Token) (any, error) {
+	if !m.exports[name.Lexeme] {
+		return nil, &RuntimeError{
+			token:   name,
+			message: fmt.Sprintf("Module '%s' does not export '%s'.", m.name, name.Lexeme),
+		}
+	}
+
+	// read from module scope on each access, so importers see later assignments of exported variables
+	return m.globals.values[name.Lexeme], nil
+}
+
+func (m *LoxModule) String() string {
+	return "<module " + m.name + ">"
+}
+
+// switch global scope to module whose code is about to run, returns previous module to restore later
+func (intr *Interpreter) enterModule(module *LoxModule) *LoxModule {
+	prevModule := intr.module
+	intr.module = module
+	intr.globals = module.globals
+	return prevModule
+}
+
+// load, run and cache module at path relative to importing module, loaded modules are reused on next import
+func (intr *Interpreter) importModule(pathToken *lexer.Token) (*LoxModule, error) {
+	name := pathToken.Literal.(string)
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(intr.module.path), path)
+	}
+	path, absErr := filepath.Abs(path)
+	if absErr != nil {
+		return nil, &RuntimeError{
+			token:   pathToken,
+			message: fmt.Sprintf("Invalid module path '%s'.", name),
+		}
+	}
+
+	if module, ok := intr.modules[path]; ok {
+		return module, nil
+	}
+
+	for i := 0; i < intr.loading.Len(); i++ {
+		if intr.loading.Get(i) == path {
+			cycle := []string{}
+			for j := i; j < intr.loading.Len(); j++ {
+				cycle = append(cycle, intr.loading.Get(j))
+			}
+			cycle = append(cycle, path)
+			return nil, &RuntimeError{
+				token:   pathToken,
+				message: fmt.Sprintf("Import cycle detected: %s.", strings.Join(cycle, " -> ")),
+			}
+		}
+	}
+
+	source, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, &RuntimeError{
+			token:   pathToken,
+			message: fmt.Sprintf("Could not read module '%s'.", name),
+		}
+	}
+
+	statements, loadErrors := intr.loadModule(string(source))
+	if len(loadErrors) > 0 {
+		// line numbers of load errors refer to module file, same as ModuleError
+		messages := make([]string, len(loadErrors))
+		for i, err := range loadErrors {
+			messages[i] = fmt.Sprintf("%s: %s", path, err)
+		}
+		return nil, &RuntimeError{
+			token:   pathToken,
+			message: fmt.Sprintf("Could not load module '%s':\n%s", name, strings.Join(messages, "\n")),
+		}
+	}
+
+	module := newLoxModule(name, path, intr.builtins)
+
+	intr.loading.Push(path)
+	prevModule := intr.enterModule(module)
+	runErr := intr.executeBlock(statements, module.globals)
+	intr.enterModule(prevModule)
+	intr.loading.Pop()
+
+	if runErr != nil {
+		return nil, moduleError(module, runErr)
+	}
+
+	intr.modules[path] = module
+	return module, nil
+}
+
+// lex, parse and resolve module source, same pipeline as Glox uses for main script
+func (intr *Interpreter) loadModule(source string) ([]ast.Stmt, []error) {
+	l := lexer.New(source)
+	tokens := l.Lex()
+	if len(l.Errors) > 0 {
+		return nil, l.Errors
+	}
+
+	p := parser.NewParser(tokens)
+	statements, _ := p.Parse()
+	if len(p.Errors) > 0 {
+		errors := make([]error, len(p.Errors))
+		for i, err := range p.Errors {
+			errors[i] = err
+		}
+		return nil, errors
+	}
+
+	resolver := NewResolver(intr)
+	resolver.Resolve(statements)
+	if len(resolver.Errors) > 0 {
+		return nil, resolver.Errors
+	}
+
+	return statements, nil
+}
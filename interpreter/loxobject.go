@@ -0,0 +1,19 @@
+package interpreter
+
+import "github.com/dydev10/glox/lexer"
+
+// LoxObject is implemented by values which have properties readable with '.' access
+type LoxObject interface {
+	Get(intr *Interpreter, name *lexer.Token) (any, error)
+}
+
+// LoxAssignable is implemented by values which have fields writable with '.' access
+type LoxAssignable interface {
+	Set(intr *Interpreter, name *lexer.Token, value any) error
+}
+
+// LoxIndexable is implemented by values which support '[]' index access and assignment
+type LoxIndexable interface {
+	GetIndex(bracket *lexer.Token, index any) (any, error)
+	SetIndex(bracket *lexer.Token, index any, value any) error
+}
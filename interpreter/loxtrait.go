@@ -0,0 +1,43 @@
+package interpreter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dydev10/glox/ast"
+)
+
+// LoxTrait is a named set of methods which classes mix in with 'with' clause
+type LoxTrait struct {
+	name    string
+	methods map[string]*LoxFunction
+}
+
+func (t *LoxTrait) String() string {
+	return fmt.Sprintf("<trait %s>", t.name)
+}
+
+// copy trait methods into class method table, methods declared by class itself take precedence.
+// same method coming from two traits is ambiguous unless class overrides it
+func mixTraits(stmt *ast.Class, traits []*LoxTrait, methods map[string]*LoxFunction) error {
+	own := maps.Clone(methods)
+	providers := make(map[string]*LoxTrait)
+	for i, trait := range traits {
+		for _, name := range slices.Sorted(maps.Keys(trait.methods)) {
+			if _, declared := own[name]; declared {
+				continue
+			}
+			if other, provided := providers[name]; provided {
+				return &RuntimeError{
+					token:   stmt.Traits[i].Name,
+					message: fmt.Sprintf("Method '%s' is provided by both traits '%s' and '%s'.", name, other.name, trait.name),
+				}
+			}
+			providers[name] = trait
+			methods[name] = trait.methods[name]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package interpreter
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// rough byte costs of runtime allocations, they only need to grow in step with real go allocations
+const (
+	valueSize       = 16 // slot holding any value in list or environment
+	entrySize       = 48 // map entry with key, value and bucket overhead
+	objectSize      = 64 // header of list, map or instance
+	environmentSize = 64
+)
+
+// MemoryLimitError ends script which allocated more than interpreter memory limit, lox catch blocks can't handle it
+type MemoryLimitError struct {
+	token *lexer.Token
+	limit int
+}
+
+func (me *MemoryLimitError) Error() string {
+	return fmt.Sprintf("[line %d] Error: Memory limit of %d bytes exceeded.", me.token.Line, me.limit)
+}
+
+func (me *MemoryLimitError) Span() lexer.Span {
+	return me.token.Span()
+}
+
+// account bytes allocated by script. once total passes the limit, values which are no longer
+// reachable are dropped from it by measuring what script still holds, so only live values count
+func (intr *Interpreter) allocate(token *lexer.Token, size int) error {
+	intr.allocated += size
+	if intr.memoryLimit > 0 && intr.allocated > intr.memoryLimit {
+		// value being allocated is not reachable yet, it is counted on top
+		intr.allocated = intr.liveBytes() + size
+		if intr.allocated > intr.memoryLimit {
+			return &MemoryLimitError{token: token, limit: intr.memoryLimit}
+		}
+	}
+	return nil
+}
+
+// approximate bytes of values reachable from builtins, loaded modules and environments of running code.
+// values held only by Go code mid evaluation are missed, they are counted once script stores them
+func (intr *Interpreter) liveBytes() int {
+	m := &memoryMeter{seen: make(map[any]bool)}
+
+	m.environment(intr.builtins)
+	m.environment(intr.globals)
+	m.environment(intr.environment)
+	for _, module := range intr.modules {
+		m.environment(module.globals)
+	}
+	for i := 0; i < intr.scopes.Len(); i++ {
+		m.environment(intr.scopes.Get(i))
+	}
+
+	return m.total
+}
+
+// memoryMeter sums sizes of values using same costs allocate charges, each object is counted once
+type memoryMeter struct {
+	seen  map[any]bool
+	total int
+}
+
+// report whether object is seen for the first time
+func (m *memoryMeter) visit(object any) bool {
+	if m.seen[object] {
+		return false
+	}
+	m.seen[object] = true
+	return true
+}
+
+func (m *memoryMeter) environment(env *Environment) {
+	for ; env != nil && m.visit(env); env = env.enclosing {
+		m.total += environmentSize + len(env.values)*entrySize
+		for _, value := range env.values {
+			m.value(value)
+		}
+	}
+}
+
+func (m *memoryMeter) value(value any) {
+	switch v := value.(type) {
+	case string:
+		// same string stored in many places shares its bytes
+		if len(v) > 0 && m.visit(unsafe.StringData(v)) {
+			m.total += len(v)
+		}
+	case *LoxList:
+		if m.visit(v) {
+			m.total += objectSize + len(v.elements)*valueSize
+			for _, element := range v.elements {
+				m.value(element)
+			}
+		}
+	case *LoxMap:
+		if m.visit(v) {
+			m.total += objectSize + len(v.keys)*entrySize
+			for _, key := range v.keys {
+				m.value(key)
+				m.value(v.entries[key])
+			}
+		}
+	case *LoxInstance:
+		if m.visit(v) {
+			m.total += objectSize + len(v.fields)*entrySize
+			m.value(v.class)
+			for _, field := range v.fields {
+				m.value(field)
+			}
+		}
+	case *LoxClass:
+		if v != nil && m.visit(v) {
+			m.total += objectSize + len(v.fields)*entrySize
+			for _, table := range []map[string]*LoxFunction{v.methods, v.getters, v.setters} {
+				for _, method := range table {
+					m.value(method)
+				}
+			}
+			for _, field := range v.fields {
+				m.value(field)
+			}
+			m.value(v.superclass)
+			m.value(v.metaclass)
+		}
+	case *LoxFunction:
+		if m.visit(v) {
+			m.total += objectSize
+			m.environment(v.closure)
+			if v.module != nil {
+				m.environment(v.module.globals)
+			}
+		}
+	case *LoxTrait:
+		if m.visit(v) {
+			m.total += objectSize
+			for _, method := range v.methods {
+				m.value(method)
+			}
+		}
+	case *LoxModule:
+		if m.visit(v) {
+			m.total += objectSize
+			m.environment(v.globals)
+		}
+	case *LoxError:
+		if m.visit(v) {
+			m.total += objectSize + len(v.message)
+		}
+	}
+}
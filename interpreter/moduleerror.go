@@ -0,0 +1,55 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// ModuleError is error raised inside module code, its line numbers refer to module file at path.
+// path is empty for main script run without one
+type ModuleError struct {
+	path string
+	err  error
+}
+
+func (me *ModuleError) Error() string {
+	if me.path == "" {
+		return me.err.Error()
+	}
+	return fmt.Sprintf("%s: %s", me.path, me.err)
+}
+
+func (me *ModuleError) Unwrap() error {
+	return me.err
+}
+
+// span inside module source
+func (me *ModuleError) Span() lexer.Span {
+	if spanned, ok := me.err.(interface{ Span() lexer.Span }); ok {
+		return spanned.Span()
+	}
+	return lexer.Span{}
+}
+
+func (me *ModuleError) Traceback() string {
+	if traced, ok := me.err.(Traced); ok {
+		return traced.Traceback()
+	}
+	return ""
+}
+
+// name module where error was raised once it leaves that module, error already naming its module is kept
+func moduleError(module *LoxModule, err error) error {
+	var wrapped *ModuleError
+	if errors.As(err, &wrapped) {
+		return err
+	}
+
+	switch err.(type) {
+	case *RuntimeError, *ThrownError, *InterruptError, *MemoryLimitError:
+		return &ModuleError{path: module.path, err: err}
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// Value is any value a lox script works with: nil, bool, float64, string or an object handed out by interpreter
+type Value = any
+
+// arity of native function which accepts any number of arguments
+const VariadicArity = -1
+
+// NativeFunction is a callable implemented in Go, used for builtins and builtin methods of native values
+type NativeFunction struct {
+	name  string
+	arity int
+	fn    func(intr *Interpreter, arguments []any) (any, error)
+}
+
+func (nf *NativeFunction) Arity() int {
+	return nf.arity
+}
+
+func (nf *NativeFunction) Call(intr *Interpreter, arguments []any) (any, error) {
+	return nf.fn(intr, arguments)
+}
+
+func (nf *NativeFunction) String() string {
+	return "<native fn>"
+}
+
+// define global function implemented by host program, visible to main script and every module.
+// arity can be VariadicArity, other negative arity is rejected. result is converted with ToValue.
+// errors returned by fn become runtime errors at call site, which scripts can catch
+func (intr *Interpreter) DefineNative(name string, arity int, fn func(args []Value) (Value, error)) error {
+	if arity < 0 && arity != VariadicArity {
+		return fmt.Errorf("invalid arity %d for native function '%s'", arity, name)
+	}
+
+	intr.builtins.define(name, &NativeFunction{
+		name:  name,
+		arity: arity,
+		fn: func(intr *Interpreter, arguments []any) (any, error) {
+			callSite := intr.callSite
+			result, err := fn(arguments)
+			if err != nil {
+				return nil, nativeError(callSite, err)
+			}
+			value, convErr := ToValue(result)
+			if convErr != nil {
+				return nil, &RuntimeError{
+					token:   callSite,
+					message: fmt.Sprintf("Native function '%s' returned %s.", name, convErr),
+				}
+			}
+			return value, nil
+		},
+	})
+	return nil
+}
+
+// define native function while creating interpreter, panics on invalid arity since options can't return errors
+func WithNative(name string, arity int, fn func(args []Value) (Value, error)) Option {
+	return func(intr *Interpreter) {
+		if err := intr.DefineNative(name, arity, fn); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// interpreter errors pass through untouched, even when host wrapped them, other host errors are reported at call site.
+// wrapped interrupt or limit error must not turn into runtime error which script could catch
+func nativeError(callSite *lexer.Token, err error) error {
+	var runtimeErr *RuntimeError
+	var thrownErr *ThrownError
+	var interruptErr *InterruptError
+	var limitErr *MemoryLimitError
+	var moduleErr *ModuleError
+	switch {
+	case errors.As(err, &interruptErr):
+		return interruptErr
+	case errors.As(err, &limitErr):
+		return limitErr
+	case errors.As(err, &moduleErr):
+		return moduleErr
+	case errors.As(err, &thrownErr):
+		return thrownErr
+	case errors.As(err, &runtimeErr):
+		return runtimeErr
+	}
+	return &RuntimeError{
+		token:   callSite,
+		message: err.Error(),
+	}
+}
+
+func isLoxValue(value any) bool {
+	switch value.(type) {
+	case nil, bool, float64, string, LoxCallable, LoxObject, LoxIndexable, *LoxTrait:
+		return true
+	}
+	return false
+}
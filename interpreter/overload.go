@@ -0,0 +1,94 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// special method names classes define to overload operators
+var operatorMethods = map[lexer.TokenType]string{
+	lexer.PLUS:          "__add",
+	lexer.MINUS:         "__sub",
+	lexer.STAR:          "__mul",
+	lexer.SLASH:         "__div",
+	lexer.GREATER:       "__gt",
+	lexer.GREATER_EQUAL: "__ge",
+	lexer.LESS:          "__lt",
+	lexer.LESS_EQUAL:    "__le",
+	lexer.EQUAL_EQUAL:   "__eq",
+}
+
+const (
+	negMethod = "__neg"
+	strMethod = "__str"
+)
+
+// call special method on instance operand, reports false when no instance operand is involved
+func (intr *Interpreter) binaryOverload(operator *lexer.Token, left, right any) (any, bool, error) {
+	instance, isInstance := left.(*LoxInstance)
+	if !isInstance {
+		// only left operand dispatches, instance on right still gets a clearer error
+		if rInstance, ok := right.(*LoxInstance); ok {
+			return nil, true, undefinedOperator(operator, rInstance)
+		}
+		return nil, false, nil
+	}
+
+	method := instance.class.FindMethod(operatorMethods[operator.Type])
+	if method == nil {
+		return nil, true, undefinedOperator(operator, instance)
+	}
+
+	result, err := intr.call(operator, method.Bind(instance), []any{right})
+	return result, true, err
+}
+
+// unary minus dispatches to __neg of instance operand
+func (intr *Interpreter) unaryOverload(operator *lexer.Token, right any) (any, bool, error) {
+	instance, isInstance := right.(*LoxInstance)
+	if !isInstance {
+		return nil, false, nil
+	}
+
+	method := instance.class.FindMethod(negMethod)
+	if method == nil {
+		return nil, true, undefinedOperator(operator, instance)
+	}
+
+	result, err := intr.call(operator, method.Bind(instance), []any{})
+	return result, true, err
+}
+
+// equality dispatches to __eq of whichever operand defines it, left first
+func (intr *Interpreter) equalOverload(operator *lexer.Token, a, b any) (bool, bool, error) {
+	instance, other := a, b
+	method := eqMethod(a)
+	if method == nil {
+		instance, other = b, a
+		method = eqMethod(b)
+	}
+	if method == nil {
+		return false, false, nil
+	}
+
+	result, err := intr.call(operator, method.Bind(instance), []any{other})
+	if err != nil {
+		return false, true, err
+	}
+	return intr.isTruthy(result), true, nil
+}
+
+func eqMethod(val any) *LoxFunction {
+	if instance, ok := val.(*LoxInstance); ok {
+		return instance.class.FindMethod(operatorMethods[lexer.EQUAL_EQUAL])
+	}
+	return nil
+}
+
+func undefinedOperator(operator *lexer.Token, instance *LoxInstance) error {
+	return &RuntimeError{
+		token:   operator,
+		message: fmt.Sprintf("Operator '%s' is not defined for %s instances.", operator.Lexeme, instance.class.name),
+	}
+}
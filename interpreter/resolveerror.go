@@ -18,3 +18,7 @@ func (re *ResolveError) String() string {
 func (re *ResolveError) Error() string {
 	return fmt.Sprintf("[line %d] Error: %s", re.token.Line, re.message)
 }
+
+func (re *ResolveError) Span() lexer.Span {
+	return re.token.Span()
+}
@@ -23,6 +23,14 @@ const (
 	ctNONE ClassType = iota
 	ctCLASS
 	ctSUBCLASS
+	ctTRAIT
+)
+
+type LoopType int
+
+const (
+	ltNONE LoopType = iota
+	ltLOOP
 )
 
 type Resolver struct {
@@ -30,6 +38,7 @@ type Resolver struct {
 	scopes          *ds.Stack[BlockScope]
 	currentFunction FunctionType
 	currentClass    ClassType
+	currentLoop     LoopType
 	Errors          []error
 }
 
@@ -39,6 +48,7 @@ func NewResolver(interpreter *Interpreter) *Resolver {
 		scopes:          ds.NewStack[BlockScope](),
 		currentFunction: ftNONE,
 		currentClass:    ctNONE,
+		currentLoop:     ltNONE,
 		Errors:          []error{},
 	}
 }
@@ -108,6 +118,9 @@ func (r *Resolver) resolveLocal(expr ast.Expr, name *lexer.Token) {
 func (r *Resolver) resolveFunction(function *ast.Function, functionType FunctionType) {
 	enclosingFunction := r.currentFunction
 	r.currentFunction = functionType
+	// loops outside of function body can't be exited from inside it
+	enclosingLoop := r.currentLoop
+	r.currentLoop = ltNONE
 
 	r.beginScope()
 	for _, param := range function.Params {
@@ -117,6 +130,7 @@ func (r *Resolver) resolveFunction(function *ast.Function, functionType Function
 	r.resolveStatements(function.Body)
 	r.endScope()
 
+	r.currentLoop = enclosingLoop
 	r.currentFunction = enclosingFunction
 }
 
@@ -132,6 +146,14 @@ func (r *Resolver) VisitBlock(stmt *ast.Block) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitBreak(stmt *ast.Break) (any, error) {
+	if r.currentLoop == ltNONE {
+		r.logError(stmt.Keyword, "Can't use 'break' outside of a loop.")
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitClass(stmt *ast.Class) (any, error) {
 	enclosingClass := r.currentClass
 	r.currentClass = ctCLASS
@@ -152,6 +174,15 @@ func (r *Resolver) VisitClass(stmt *ast.Class) (any, error) {
 		r.scopes.Peek()["super"] = true
 	}
 
+	used := make(map[string]bool)
+	for _, trait := range stmt.Traits {
+		if used[trait.Name.Lexeme] {
+			r.logError(trait.Name, "A class can't use the same trait twice.")
+		}
+		used[trait.Name.Lexeme] = true
+		r.resolveExpr(trait)
+	}
+
 	r.beginScope()
 	r.scopes.Peek()["this"] = true
 
@@ -163,6 +194,25 @@ func (r *Resolver) VisitClass(stmt *ast.Class) (any, error) {
 		r.resolveFunction(method, declaration)
 	}
 
+	for _, getter := range stmt.Getters {
+		if len(getter.Params) != 0 {
+			r.logError(getter.Name, "A getter can't take parameters.")
+		}
+		r.resolveFunction(getter, ftMETHOD)
+	}
+
+	for _, setter := range stmt.Setters {
+		if len(setter.Params) != 1 {
+			r.logError(setter.Name, "A setter must take exactly one parameter.")
+		}
+		r.resolveFunction(setter, ftMETHOD)
+	}
+
+	// 'this' inside class methods is the class itself, so they share the same scope
+	for _, method := range stmt.ClassMethods {
+		r.resolveFunction(method, ftMETHOD)
+	}
+
 	r.endScope()
 	if stmt.Superclass != nil {
 		r.endScope()
@@ -173,6 +223,29 @@ func (r *Resolver) VisitClass(stmt *ast.Class) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitTrait(stmt *ast.Trait) (any, error) {
+	enclosingClass := r.currentClass
+	r.currentClass = ctTRAIT
+
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+
+	r.beginScope()
+	r.scopes.Peek()["this"] = true
+
+	for _, method := range stmt.Methods {
+		if method.Name.Lexeme == "init" {
+			r.logError(method.Name, "A trait can't define an initializer.")
+		}
+		r.resolveFunction(method, ftMETHOD)
+	}
+
+	r.endScope()
+	r.currentClass = enclosingClass
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitVar(stmt *ast.Var) (any, error) {
 	r.declare(stmt.Name)
 	if stmt.Initializer != nil {
@@ -191,6 +264,23 @@ func (r *Resolver) VisitFunction(stmt *ast.Function) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitContinue(stmt *ast.Continue) (any, error) {
+	if r.currentLoop == ltNONE {
+		r.logError(stmt.Keyword, "Can't use 'continue' outside of a loop.")
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitExport(stmt *ast.Export) (any, error) {
+	if !r.scopes.IsEmpty() {
+		r.logError(stmt.Keyword, "Can only export top-level declarations.")
+	}
+	r.resolveStmt(stmt.Declaration)
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitExpression(stmt *ast.Expression) (any, error) {
 	r.resolveExpr(stmt.Expression)
 
@@ -207,6 +297,19 @@ func (r *Resolver) VisitIf(stmt *ast.If) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitImport(stmt *ast.Import) (any, error) {
+	if stmt.Alias != nil {
+		r.declare(stmt.Alias)
+		r.define(stmt.Alias)
+	}
+	for _, name := range stmt.Names {
+		r.declare(name)
+		r.define(name)
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitPrint(stmt *ast.Print) (any, error) {
 	r.resolveExpr(stmt.Expression)
 
@@ -230,9 +333,46 @@ func (r *Resolver) VisitReturn(stmt *ast.Return) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitThrow(stmt *ast.Throw) (any, error) {
+	r.resolveExpr(stmt.Value)
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitTry(stmt *ast.Try) (any, error) {
+	r.beginScope()
+	r.resolveStatements(stmt.Body)
+	r.endScope()
+
+	if stmt.CatchName != nil {
+		// error variable lives in same scope as catch body
+		r.beginScope()
+		r.declare(stmt.CatchName)
+		r.define(stmt.CatchName)
+		r.resolveStatements(stmt.CatchBody)
+		r.endScope()
+	}
+
+	if stmt.FinallyBody != nil {
+		r.beginScope()
+		r.resolveStatements(stmt.FinallyBody)
+		r.endScope()
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitWhile(stmt *ast.While) (any, error) {
+	enclosingLoop := r.currentLoop
+	r.currentLoop = ltLOOP
+
 	r.resolveExpr(stmt.Condition)
 	r.resolveStmt(stmt.Body)
+	if stmt.Increment != nil {
+		r.resolveExpr(stmt.Increment)
+	}
+
+	r.currentLoop = enclosingLoop
 
 	return nil, nil
 }
@@ -289,6 +429,43 @@ func (r *Resolver) VisitGrouping(expr *ast.Grouping) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitIndexGet(expr *ast.IndexGet) (any, error) {
+	r.resolveExpr(expr.Object)
+	r.resolveExpr(expr.Index)
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitIndexSet(expr *ast.IndexSet) (any, error) {
+	r.resolveExpr(expr.Value)
+	r.resolveExpr(expr.Object)
+	r.resolveExpr(expr.Index)
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitInterpolation(expr *ast.Interpolation) (any, error) {
+	for _, part := range expr.Parts {
+		r.resolveExpr(part)
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitLambda(expr *ast.Lambda) (any, error) {
+	r.resolveFunction(expr.Function, ftFUNCTION)
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitList(expr *ast.List) (any, error) {
+	for _, element := range expr.Elements {
+		r.resolveExpr(element)
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitLiteral(expr *ast.Literal) (any, error) {
 	return nil, nil
 }
@@ -300,6 +477,23 @@ func (r *Resolver) VisitLogical(expr *ast.Logical) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitTernary(expr *ast.Ternary) (any, error) {
+	r.resolveExpr(expr.Condition)
+	r.resolveExpr(expr.ThenBranch)
+	r.resolveExpr(expr.ElseBranch)
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitMap(expr *ast.Map) (any, error) {
+	for i := range expr.Keys {
+		r.resolveExpr(expr.Keys[i])
+		r.resolveExpr(expr.Values[i])
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitThis(expr *ast.This) (any, error) {
 	if r.currentClass == ctNONE {
 		r.logError(expr.Keyword, "Can't use 'this' outside of a class.")
@@ -318,15 +512,28 @@ func (r *Resolver) VisitSet(expr *ast.Set) (any, error) {
 }
 
 func (r *Resolver) VisitSuper(expr *ast.Super) (any, error) {
+	r.resolveSuper(expr, expr.Keyword)
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitSuperSet(expr *ast.SuperSet) (any, error) {
+	r.resolveExpr(expr.Value)
+	r.resolveSuper(expr, expr.Keyword)
+
+	return nil, nil
+}
+
+func (r *Resolver) resolveSuper(expr ast.Expr, keyword *lexer.Token) {
 	if r.currentClass == ctNONE {
-		r.logError(expr.Keyword, "Can't use 'super' outside of a class.")
+		r.logError(keyword, "Can't use 'super' outside of a class.")
+	} else if r.currentClass == ctTRAIT {
+		r.logError(keyword, "Can't use 'super' in a trait.")
 	} else if r.currentClass != ctSUBCLASS {
-		r.logError(expr.Keyword, "Can't use 'super' in a class with no superclass.")
+		r.logError(keyword, "Can't use 'super' in a class with no superclass.")
 	}
 
-	r.resolveLocal(expr, expr.Keyword)
-
-	return nil, nil
+	r.resolveLocal(expr, keyword)
 }
 
 func (r *Resolver) VisitUnary(expr *ast.Unary) (any, error) {
@@ -7,8 +7,9 @@ import (
 )
 
 type RuntimeError struct {
-	token   *lexer.Token
-	message string
+	token     *lexer.Token
+	message   string
+	traceback string // call stack when error left innermost lox function, empty if raised at top level
 }
 
 func (re *RuntimeError) String() string {
@@ -18,3 +19,11 @@ func (re *RuntimeError) String() string {
 func (re *RuntimeError) Error() string {
 	return fmt.Sprintf("[line %d] Error: %s", re.token.Line, re.message)
 }
+
+func (re *RuntimeError) Span() lexer.Span {
+	return re.token.Span()
+}
+
+func (re *RuntimeError) Traceback() string {
+	return re.traceback
+}
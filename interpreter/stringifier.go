@@ -0,0 +1,98 @@
+package interpreter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// stringifier is the single path turning values into printed text. containers being printed are tracked,
+// so that one which contains itself prints as [...] or {...} instead of recursing forever
+type stringifier struct {
+	intr     *Interpreter // runs __str methods of instances, nil prints instances in plain form
+	callSite *lexer.Token // where stringifying was triggered, __str errors and tracebacks point here
+	visiting map[any]bool
+}
+
+func newStringifier(intr *Interpreter, callSite *lexer.Token) *stringifier {
+	return &stringifier{
+		intr:     intr,
+		callSite: callSite,
+		visiting: make(map[any]bool),
+	}
+}
+
+// stringify value for output, unlike PrintEvaluation __str methods run and their errors are reported
+func (intr *Interpreter) stringify(callSite *lexer.Token, val any) (string, error) {
+	return newStringifier(intr, callSite).stringify(val)
+}
+
+func (s *stringifier) stringify(val any) (string, error) {
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil // no .0 needed at end
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "nil", nil
+	case *LoxInstance:
+		// instance whose __str is already running prints in plain form, __str returning 'this' can't recurse
+		method := v.class.FindMethod(strMethod)
+		if method == nil || s.intr == nil || s.visiting[v] {
+			return v.String(), nil
+		}
+		s.visiting[v] = true
+		defer delete(s.visiting, v)
+
+		result, err := s.intr.call(s.callSite, method.Bind(v), []any{})
+		if err != nil {
+			return "", err
+		}
+		return s.stringify(result)
+	case *LoxList:
+		if s.visiting[v] {
+			return "[...]", nil
+		}
+		s.visiting[v] = true
+		defer delete(s.visiting, v)
+
+		parts := make([]string, len(v.elements))
+		for i, element := range v.elements {
+			part, err := s.stringify(element)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = part
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	case *LoxMap:
+		if s.visiting[v] {
+			return "{...}", nil
+		}
+		s.visiting[v] = true
+		defer delete(s.visiting, v)
+
+		parts := make([]string, len(v.keys))
+		for i, key := range v.keys {
+			keyPart, err := s.stringify(key)
+			if err != nil {
+				return "", err
+			}
+			valuePart, err := s.stringify(v.entries[key])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = keyPart + ": " + valuePart
+		}
+		return "{" + strings.Join(parts, ", ") + "}", nil
+	case fmt.Stringer:
+		return v.String(), nil
+	default:
+		e := fmt.Sprintf("Unknown value type evaluated by interpreter: %v", v)
+		panic(e)
+	}
+}
@@ -0,0 +1,8 @@
+package interpreter
+
+type ThrownBreak struct {
+}
+
+func (tb *ThrownBreak) Error() string {
+	return "Not an error. If this shows up as error in logs, something is wrong with handling of break statement"
+}
@@ -0,0 +1,8 @@
+package interpreter
+
+type ThrownContinue struct {
+}
+
+func (tc *ThrownContinue) Error() string {
+	return "Not an error. If this shows up as error in logs, something is wrong with handling of continue statement"
+}
@@ -0,0 +1,27 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// ThrownError carries value of throw statement up the call stack until a catch block handles it
+type ThrownError struct {
+	token     *lexer.Token
+	value     any
+	message   string // thrown value as text, formatted with __str at throw
+	traceback string
+}
+
+func (te *ThrownError) Error() string {
+	return fmt.Sprintf("[line %d] Error: Uncaught exception: %s", te.token.Line, te.message)
+}
+
+func (te *ThrownError) Span() lexer.Span {
+	return te.token.Span()
+}
+
+func (te *ThrownError) Traceback() string {
+	return te.traceback
+}
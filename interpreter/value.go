@@ -0,0 +1,361 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+// ToValue converts Go value to lox value. numbers become float64, slices and arrays lists,
+// maps lox maps and struct pointers objects whose fields and methods scripts can use
+func ToValue(value any) (Value, error) {
+	return toValue(reflect.ValueOf(value))
+}
+
+// FromValue stores lox value into Go variable which target points to, converting it to variable's type
+func FromValue(value Value, target any) error {
+	pointer := reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Pointer || pointer.IsNil() {
+		return fmt.Errorf("target must be non-nil pointer, got %T", target)
+	}
+
+	converted, err := fromValue(value, pointer.Elem().Type())
+	if err != nil {
+		return err
+	}
+	pointer.Elem().Set(converted)
+	return nil
+}
+
+func toValue(value reflect.Value) (any, error) {
+	c := &goConverter{visiting: make(map[goRef]bool)}
+	return c.toValue(value)
+}
+
+// goConverter tracks maps, slices and pointers being converted, Go value which contains itself
+// would otherwise recurse until host process runs out of stack
+type goConverter struct {
+	visiting map[goRef]bool
+}
+
+// identity of Go container, slices sharing backing array differ by length
+type goRef struct {
+	pointer unsafe.Pointer
+	typ     reflect.Type
+	length  int
+}
+
+// mark container as being converted, fails when it is already converted further up
+func (c *goConverter) enter(value reflect.Value) (goRef, error) {
+	ref := goRef{pointer: value.UnsafePointer(), typ: value.Type()}
+	if value.Kind() == reflect.Slice {
+		ref.length = value.Len()
+	}
+	if c.visiting[ref] {
+		return ref, fmt.Errorf("Go value of type %s contains itself", value.Type())
+	}
+	c.visiting[ref] = true
+	return ref, nil
+}
+
+func (c *goConverter) toValue(value reflect.Value) (any, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+	// values handed out by interpreter go back unchanged
+	if value.CanInterface() && isLoxValue(value.Interface()) {
+		return value.Interface(), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return c.toValue(value.Elem())
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, nil
+		}
+		if value.Elem().Kind() == reflect.Struct {
+			return &GoObject{value: value}, nil
+		}
+		ref, err := c.enter(value)
+		if err != nil {
+			return nil, err
+		}
+		defer delete(c.visiting, ref)
+		return c.toValue(value.Elem())
+	case reflect.Struct:
+		// struct inside host value stays live, so that script writes to its fields reach host
+		if value.CanAddr() {
+			return &GoObject{value: value.Addr()}, nil
+		}
+		// standalone struct is copied, script changes to its fields are not seen by host
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		return &GoObject{value: pointer}, nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice {
+			if value.IsNil() {
+				return nil, nil
+			}
+			ref, err := c.enter(value)
+			if err != nil {
+				return nil, err
+			}
+			defer delete(c.visiting, ref)
+		}
+		elements := make([]any, value.Len())
+		for i := range elements {
+			element, err := c.toValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+		}
+		return &LoxList{elements: elements}, nil
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+		ref, err := c.enter(value)
+		if err != nil {
+			return nil, err
+		}
+		defer delete(c.visiting, ref)
+		return c.toMap(value)
+	}
+
+	return nil, fmt.Errorf("unsupported Go type %s", value.Type())
+}
+
+// convert Go map, keys are sorted since go map order is random and lox maps keep insertion order
+func (c *goConverter) toMap(value reflect.Value) (any, error) {
+	m := NewLoxMap()
+	for _, goKey := range value.MapKeys() {
+		key, err := c.toValue(goKey)
+		if err != nil {
+			return nil, err
+		}
+		if key, err = mapKey(nil, key); err != nil {
+			return nil, fmt.Errorf("unsupported Go map key type %s", goKey.Type())
+		}
+		element, err := c.toValue(value.MapIndex(goKey))
+		if err != nil {
+			return nil, err
+		}
+		m.set(key, element)
+	}
+
+	slices.SortFunc(m.keys, compareKeys)
+	return m, nil
+}
+
+// order map keys by type first, then by value
+func compareKeys(a, b any) int {
+	aStr, isStrA := a.(string)
+	bStr, isStrB := b.(string)
+	if isStrA && isStrB {
+		if aStr < bStr {
+			return -1
+		} else if aStr > bStr {
+			return 1
+		}
+		return 0
+	}
+	aNum, isNumA := a.(float64)
+	bNum, isNumB := b.(float64)
+	if isNumA && isNumB {
+		if aNum < bNum {
+			return -1
+		} else if aNum > bNum {
+			return 1
+		}
+		return 0
+	}
+	return keyRank(a) - keyRank(b)
+}
+
+func keyRank(key any) int {
+	switch key.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case float64:
+		return 2
+	}
+	return 3
+}
+
+func fromValue(value any, target reflect.Type) (reflect.Value, error) {
+	if target.Kind() == reflect.Interface {
+		if value == nil {
+			return reflect.Zero(target), nil
+		}
+		goValue := reflect.ValueOf(interfaceValue(value))
+		if goValue.Type().AssignableTo(target) {
+			return goValue, nil
+		}
+		return reflect.Value{}, conversionError(value, target)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		switch target.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+			return reflect.Zero(target), nil
+		}
+	case bool:
+		if target.Kind() == reflect.Bool {
+			return reflect.ValueOf(v).Convert(target), nil
+		}
+	case float64:
+		return numberValue(v, target)
+	case string:
+		if target.Kind() == reflect.String {
+			return reflect.ValueOf(v).Convert(target), nil
+		}
+	case *LoxList:
+		return listValue(v, target)
+	case *LoxMap:
+		if target.Kind() == reflect.Map {
+			return mapValue(v, target)
+		}
+	case *GoObject:
+		if v.value.Type().AssignableTo(target) {
+			return v.value, nil
+		}
+		if v.value.Elem().Type().AssignableTo(target) {
+			return v.value.Elem(), nil
+		}
+	}
+
+	return reflect.Value{}, conversionError(value, target)
+}
+
+func numberValue(number float64, target reflect.Type) (reflect.Value, error) {
+	result := reflect.New(target).Elem()
+
+	switch target.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if result.OverflowFloat(number) {
+			return reflect.Value{}, fmt.Errorf("number %v overflows Go type %s", number, target)
+		}
+		result.SetFloat(number)
+		return result, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if number != math.Trunc(number) || number < math.MinInt64 || number >= math.MaxInt64 || result.OverflowInt(int64(number)) {
+			return reflect.Value{}, fmt.Errorf("number %v doesn't fit Go type %s", number, target)
+		}
+		result.SetInt(int64(number))
+		return result, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if number != math.Trunc(number) || number < 0 || number >= math.MaxUint64 || result.OverflowUint(uint64(number)) {
+			return reflect.Value{}, fmt.Errorf("number %v doesn't fit Go type %s", number, target)
+		}
+		result.SetUint(uint64(number))
+		return result, nil
+	}
+
+	return reflect.Value{}, conversionError(number, target)
+}
+
+func listValue(list *LoxList, target reflect.Type) (reflect.Value, error) {
+	var result reflect.Value
+	switch target.Kind() {
+	case reflect.Slice:
+		result = reflect.MakeSlice(target, len(list.elements), len(list.elements))
+	case reflect.Array:
+		if target.Len() != len(list.elements) {
+			return reflect.Value{}, fmt.Errorf("list of length %d doesn't fit Go type %s", len(list.elements), target)
+		}
+		result = reflect.New(target).Elem()
+	default:
+		return reflect.Value{}, conversionError(list, target)
+	}
+
+	for i, element := range list.elements {
+		converted, err := fromValue(element, target.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.Index(i).Set(converted)
+	}
+	return result, nil
+}
+
+func mapValue(m *LoxMap, target reflect.Type) (reflect.Value, error) {
+	result := reflect.MakeMapWithSize(target, len(m.keys))
+	for _, key := range m.keys {
+		goKey, err := fromValue(key, target.Key())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		element, err := fromValue(m.entries[key], target.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetMapIndex(goKey, element)
+	}
+	return result, nil
+}
+
+// natural Go form of lox value stored in interface typed variable
+func interfaceValue(value any) any {
+	switch v := value.(type) {
+	case *LoxList:
+		elements := make([]any, len(v.elements))
+		for i, element := range v.elements {
+			elements[i] = interfaceValue(element)
+		}
+		return elements
+	case *LoxMap:
+		entries := make(map[any]any, len(v.keys))
+		for _, key := range v.keys {
+			entries[key] = interfaceValue(v.entries[key])
+		}
+		return entries
+	case *GoObject:
+		return v.value.Interface()
+	}
+	return value
+}
+
+func conversionError(value any, target reflect.Type) error {
+	return fmt.Errorf("can't convert %s to Go type %s", typeName(value), target)
+}
+
+// name of lox value type used in conversion errors
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case *LoxList:
+		return "list"
+	case *LoxMap:
+		return "map"
+	case LoxCallable:
+		return "function"
+	}
+	return "object"
+}
@@ -3,36 +3,51 @@ package lexer
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 var keywords = map[string]TokenType{
-	"and":    AND,
-	"class":  CLASS,
-	"else":   ELSE,
-	"false":  FALSE,
-	"for":    FOR,
-	"fun":    FUN,
-	"if":     IF,
-	"nil":    NIL,
-	"or":     OR,
-	"print":  PRINT,
-	"return": RETURN,
-	"super":  SUPER,
-	"this":   THIS,
-	"true":   TRUE,
-	"var":    VAR,
-	"while":  WHILE,
+	"and":      AND,
+	"break":    BREAK,
+	"catch":    CATCH,
+	"class":    CLASS,
+	"continue": CONTINUE,
+	"else":     ELSE,
+	"export":   EXPORT,
+	"false":    FALSE,
+	"finally":  FINALLY,
+	"for":      FOR,
+	"fun":      FUN,
+	"if":       IF,
+	"import":   IMPORT,
+	"nil":      NIL,
+	"or":       OR,
+	"print":    PRINT,
+	"return":   RETURN,
+	"super":    SUPER,
+	"this":     THIS,
+	"throw":    THROW,
+	"true":     TRUE,
+	"try":      TRY,
+	"var":      VAR,
+	"while":    WHILE,
 }
 
 // Lexer holds the input and current scan position
 type Lexer struct {
-	source  string
-	start   int
-	current int
-	line    int
-	tokens  []*Token
-	Errors  []error
+	source      string
+	start       int
+	current     int
+	line        int
+	lineStart   int // byte offset where current line begins, used for columns
+	startLine   int
+	startColumn int
+	// open brace count for each string interpolation being lexed, innermost last
+	interpolations []int
+	tokens         []*Token
+	Errors         []error
 }
 
 func New(source string) *Lexer {
@@ -47,20 +62,38 @@ func New(source string) *Lexer {
 func (l *Lexer) Lex() []*Token {
 	for !l.isAtEnd() {
 		l.start = l.current
+		l.startLine = l.line
+		l.startColumn = l.column(l.start)
 		ch := l.advance()
 
 		switch {
 		case ch != '\n' && isWhitespace(ch):
 		case ch == '\n':
-			l.line++
+			l.newline()
 		case ch == '(':
 			l.addToken(LEFT_PAREN, nil)
 		case ch == ')':
 			l.addToken(RIGHT_PAREN, nil)
 		case ch == '{':
+			if n := len(l.interpolations); n > 0 {
+				l.interpolations[n-1]++
+			}
 			l.addToken(LEFT_BRACE, nil)
 		case ch == '}':
-			l.addToken(RIGHT_BRACE, nil)
+			if n := len(l.interpolations); n > 0 && l.interpolations[n-1] == 0 {
+				// closes ${ expression, continue lexing rest of the string
+				l.interpolations = l.interpolations[:n-1]
+				l.lexString()
+			} else {
+				if n > 0 {
+					l.interpolations[n-1]--
+				}
+				l.addToken(RIGHT_BRACE, nil)
+			}
+		case ch == '[':
+			l.addToken(LEFT_BRACKET, nil)
+		case ch == ']':
+			l.addToken(RIGHT_BRACKET, nil)
 		case ch == ',':
 			l.addToken(COMMA, nil)
 		case ch == '.':
@@ -73,6 +106,10 @@ func (l *Lexer) Lex() []*Token {
 			l.addToken(SEMICOLON, nil)
 		case ch == '*':
 			l.addToken(STAR, nil)
+		case ch == '?':
+			l.addToken(QUESTION, nil)
+		case ch == ':':
+			l.addToken(COLON, nil)
 		case ch == '!':
 			if l.match('=') {
 				l.addToken(BANG_EQUAL, nil)
@@ -82,6 +119,8 @@ func (l *Lexer) Lex() []*Token {
 		case ch == '=':
 			if l.match('=') {
 				l.addToken(EQUAL_EQUAL, nil)
+			} else if l.match('>') {
+				l.addToken(ARROW, nil)
 			} else {
 				l.addToken(EQUAL, nil)
 			}
@@ -100,6 +139,8 @@ func (l *Lexer) Lex() []*Token {
 		case ch == '/':
 			if l.match('/') {
 				l.skipComment()
+			} else if l.match('*') {
+				l.skipBlockComment()
 			} else {
 				l.addToken(SLASH, nil)
 			}
@@ -115,7 +156,25 @@ func (l *Lexer) Lex() []*Token {
 		}
 	}
 
-	l.tokens = append(l.tokens, &Token{Type: EOF, Lexeme: "", Literal: nil})
+	if len(l.interpolations) > 0 {
+		l.start = l.current
+		l.startLine = l.line
+		l.startColumn = l.column(l.start)
+		l.logError("Unterminated string interpolation.")
+	}
+
+	endColumn := l.column(l.current)
+	l.tokens = append(l.tokens, &Token{
+		Type:      EOF,
+		Lexeme:    "",
+		Literal:   nil,
+		Line:      l.line,
+		Column:    endColumn,
+		EndLine:   l.line,
+		EndColumn: endColumn,
+		Start:     l.current,
+		End:       l.current,
+	})
 	return l.tokens
 }
 
@@ -132,13 +191,29 @@ func (l *Lexer) advance() rune {
 func (l *Lexer) addToken(t TokenType, literal any) {
 	text := l.source[l.start:l.current]
 	l.tokens = append(l.tokens, &Token{
-		Type:    t,
-		Lexeme:  text,
-		Literal: literal,
-		Line:    l.line,
+		Type:      t,
+		Lexeme:    text,
+		Literal:   literal,
+		Line:      l.startLine,
+		Column:    l.startColumn,
+		EndLine:   l.line,
+		EndColumn: l.column(l.current),
+		Start:     l.start,
+		End:       l.current,
 	})
 }
 
+// column of byte offset on current line, 1-based
+func (l *Lexer) column(offset int) int {
+	return offset - l.lineStart + 1
+}
+
+// call after consuming '\n' to move line counter and column origin to next line
+func (l *Lexer) newline() {
+	l.line++
+	l.lineStart = l.current
+}
+
 func (l *Lexer) match(expected rune) bool {
 	if l.isAtEnd() || l.peek() != expected {
 		return false
@@ -167,13 +242,57 @@ func (l *Lexer) skipComment() {
 	}
 }
 
+// skip /* ... */ comment, nested comments must be closed before outer one ends
+func (l *Lexer) skipBlockComment() {
+	depth := 1
+	for depth > 0 {
+		if l.isAtEnd() {
+			l.logErrorAtStart("Unterminated comment.")
+			return
+		}
+
+		ch := l.advance()
+		switch {
+		case ch == '\n':
+			l.newline()
+		case ch == '/' && l.match('*'):
+			depth++
+		case ch == '*' && l.match('/'):
+			depth--
+		}
+	}
+}
+
+// lex string literal, or the segment of it up to next ${ which is emitted as INTERPOLATION token
 func (l *Lexer) lexString() {
+	var value strings.Builder
+	valid := true
+
 	for l.peek() != '"' && !l.isAtEnd() {
-		if l.peek() == '\n' {
+		ch := l.advance()
+		switch ch {
+		case '\n':
 			// allow multiline string, inc line count
-			l.line++
+			l.newline()
+			value.WriteByte('\n')
+		case '\\':
+			if !l.lexEscape(&value) {
+				valid = false
+			}
+		case '$':
+			if l.match('{') {
+				// lexer continues with embedded expression tokens until matching '}'
+				l.interpolations = append(l.interpolations, 0)
+				if valid {
+					l.addToken(INTERPOLATION, value.String())
+				}
+				return
+			}
+			value.WriteByte('$')
+		default:
+			// copy raw bytes so that utf-8 text inside string stays intact
+			value.WriteByte(byte(ch))
 		}
-		l.advance()
 	}
 
 	if l.isAtEnd() {
@@ -183,23 +302,128 @@ func (l *Lexer) lexString() {
 
 	// closing "
 	l.advance()
-	value := l.source[l.start+1 : l.current-1]
-	l.addToken(STRING, value)
+	if valid {
+		l.addToken(STRING, value.String())
+	}
+}
+
+// decode escape sequence after '\\' into value, reports false when escape is invalid
+func (l *Lexer) lexEscape(value *strings.Builder) bool {
+	escStart := l.current - 1
+	escColumn := l.column(escStart)
+
+	if l.isAtEnd() {
+		// unterminated string error is logged by caller
+		return false
+	}
+
+	ch := l.advance()
+	switch ch {
+	case 'n':
+		value.WriteByte('\n')
+	case 't':
+		value.WriteByte('\t')
+	case 'r':
+		value.WriteByte('\r')
+	case '"':
+		value.WriteByte('"')
+	case '\\':
+		value.WriteByte('\\')
+	case '$':
+		value.WriteByte('$')
+	case 'u':
+		r, ok := l.lexUnicodeEscape()
+		if !ok {
+			l.logErrorFrom(escStart, escColumn, "Invalid unicode escape sequence.")
+			return false
+		}
+		value.WriteRune(r)
+	default:
+		l.logErrorFrom(escStart, escColumn, fmt.Sprintf("Invalid escape sequence: \\%c", ch))
+		if ch == '\n' {
+			l.newline()
+		}
+		return false
+	}
+
+	return true
+}
+
+// lex {hex digits} part of \u{...} escape
+func (l *Lexer) lexUnicodeEscape() (rune, bool) {
+	if !l.match('{') {
+		return 0, false
+	}
+
+	digitsStart := l.current
+	for isHexDigit(l.peek()) {
+		l.advance()
+	}
+	digits := l.source[digitsStart:l.current]
+
+	if !l.match('}') || len(digits) == 0 || len(digits) > 6 {
+		return 0, false
+	}
+
+	code, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, false
+	}
+	return rune(code), true
 }
 
 func (l *Lexer) lexNumber() {
-	for isDigit(l.peek()) {
+	if l.source[l.start] == '0' && (l.peek() == 'x' || l.peek() == 'X') {
+		l.advance()
+		l.lexRadixNumber(16, isHexDigit, "Invalid hexadecimal number.")
+		return
+	}
+	if l.source[l.start] == '0' && (l.peek() == 'b' || l.peek() == 'B') {
 		l.advance()
+		l.lexRadixNumber(2, isBinaryDigit, "Invalid binary number.")
+		return
 	}
 
+	// first digit is already consumed, so integer part digits continue from start of lexeme
+	l.current = l.start
+	intPart, ok := l.lexDigits(isDigit)
+
+	var fraction string
 	if l.peek() == '.' && isDigit(l.peekNext()) {
 		l.advance()
-		for !l.isAtEnd() && isDigit(l.peek()) {
+		digits, fractionOk := l.lexDigits(isDigit)
+		fraction = "." + digits
+		ok = ok && fractionOk
+	}
+
+	var exponent string
+	if l.isExponentStart() {
+		l.advance()
+		sign := ""
+		if l.peek() == '+' || l.peek() == '-' {
+			sign = string(l.advance())
+		}
+		digits, exponentOk := l.lexDigits(isDigit)
+		exponent = "e" + sign + digits
+		ok = ok && exponentOk
+	} else if (l.peek() == 'e' || l.peek() == 'E') && !isAlphaNumeric(l.peekNext()) {
+		// dangling exponent like 1e or 1e+, while letters after e start identifier as in 2else
+		l.advance()
+		if l.peek() == '+' || l.peek() == '-' {
 			l.advance()
 		}
+		l.logError("Expect digits in number exponent.")
+		return
 	}
 
-	value, err := strconv.ParseFloat(l.source[l.start:l.current], 64)
+	// identifier right after digits is lexed as separate token
+	if !ok {
+		l.skipAlphaNumeric()
+		l.logError("Invalid number.")
+		return
+	}
+
+	value, err := strconv.ParseFloat(intPart+fraction+exponent, 64)
 	if err != nil {
 		l.logError("Number cannot be parsed to float64")
 		return
@@ -207,6 +431,69 @@ func (l *Lexer) lexNumber() {
 	l.addToken(NUMBER, value)
 }
 
+// e/E starts exponent only when digits follow it, optionally after sign
+func (l *Lexer) isExponentStart() bool {
+	if l.peek() != 'e' && l.peek() != 'E' {
+		return false
+	}
+	next := l.current + 1
+	if next < len(l.source) && (l.source[next] == '+' || l.source[next] == '-') {
+		next++
+	}
+	return next < len(l.source) && isDigit(rune(l.source[next]))
+}
+
+// lex digits of 0x / 0b literal after its prefix
+func (l *Lexer) lexRadixNumber(base int, isRadixDigit func(rune) bool, message string) {
+	if !isRadixDigit(l.peek()) {
+		l.skipAlphaNumeric()
+		l.logError(message)
+		return
+	}
+
+	// letter or out of range digit right after digits, like 0b102 or 0xFFg, is part of malformed literal
+	digits, ok := l.lexDigits(isRadixDigit)
+	if !ok || isAlphaNumeric(l.peek()) {
+		l.skipAlphaNumeric()
+		l.logError(message)
+		return
+	}
+
+	value, err := strconv.ParseUint(digits, base, 64)
+	if err != nil {
+		l.logError("Number cannot be parsed to float64")
+		return
+	}
+	l.addToken(NUMBER, float64(value))
+}
+
+// consume run of digits which may be separated by single '_', returns digits without separators
+// reports false when '_' is not placed between two digits
+func (l *Lexer) lexDigits(isValidDigit func(rune) bool) (string, bool) {
+	var digits strings.Builder
+	ok := true
+
+	for isValidDigit(l.peek()) || l.peek() == '_' {
+		ch := l.advance()
+		if ch == '_' {
+			if !isValidDigit(l.peek()) {
+				ok = false
+			}
+			continue
+		}
+		digits.WriteByte(byte(ch))
+	}
+
+	return digits.String(), ok
+}
+
+// skip rest of malformed literal so that it is reported as single error
+func (l *Lexer) skipAlphaNumeric() {
+	for !l.isAtEnd() && isAlphaNumeric(l.peek()) {
+		l.advance()
+	}
+}
+
 func (l *Lexer) lexIdentifier() {
 	for !l.isAtEnd() && isAlphaNumeric(l.peek()) {
 		l.advance()
@@ -227,6 +514,14 @@ func isDigit(ch rune) bool {
 	return unicode.IsDigit(ch)
 }
 
+func isHexDigit(ch rune) bool {
+	return (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+func isBinaryDigit(ch rune) bool {
+	return ch == '0' || ch == '1'
+}
+
 func isAlpha(ch rune) bool {
 	return unicode.IsLetter(ch) || ch == '_'
 }
@@ -236,8 +531,41 @@ func isAlphaNumeric(ch rune) bool {
 }
 
 func (l *Lexer) logError(message string) {
+	l.appendError(l.line, message, l.lexemeSpan())
+}
+
+// log error on the line where current lexeme started, for errors about unclosed constructs
+func (l *Lexer) logErrorAtStart(message string) {
+	l.appendError(l.startLine, message, l.lexemeSpan())
+}
+
+// log error for part of current lexeme starting at byte offset start on current line
+func (l *Lexer) logErrorFrom(start, column int, message string) {
+	l.appendError(l.line, message, Span{
+		Start:     start,
+		End:       l.current,
+		Line:      l.line,
+		Column:    column,
+		EndLine:   l.line,
+		EndColumn: l.column(l.current),
+	})
+}
+
+func (l *Lexer) lexemeSpan() Span {
+	return Span{
+		Start:     l.start,
+		End:       l.current,
+		Line:      l.startLine,
+		Column:    l.startColumn,
+		EndLine:   l.line,
+		EndColumn: l.column(l.current),
+	}
+}
+
+func (l *Lexer) appendError(line int, message string, span Span) {
 	l.Errors = append(l.Errors, LexError{
-		line:    l.line,
+		line:    line,
 		message: message,
+		span:    span,
 	})
 }
@@ -0,0 +1,73 @@
+package lexer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dydev10/glox/lexer"
+)
+
+// tokens of source without EOF, formatted as in tokenize output
+func tokenize(source string) ([]string, []error) {
+	l := lexer.New(source)
+	var tokens []string
+	for _, token := range l.Lex() {
+		if token.Type != lexer.EOF {
+			tokens = append(tokens, token.String())
+		}
+	}
+	return tokens, l.Errors
+}
+
+func TestNumberLiterals(t *testing.T) {
+	for _, tc := range []struct {
+		source string
+		want   string
+	}{
+		{"0xFF", "NUMBER 0xFF 255.0"},
+		{"0b1010", "NUMBER 0b1010 10.0"},
+		{"1_000_000", "NUMBER 1_000_000 1000000.0"},
+		{"6.02e23", "NUMBER 6.02e23 602000000000000000000000.0"},
+		{"1.5E-3", "NUMBER 1.5E-3 0.0015"},
+		{"2e+2", "NUMBER 2e+2 200.0"},
+		{"123abc", "NUMBER 123 123.0|IDENTIFIER abc null"},
+		{"2else", "NUMBER 2 2.0|ELSE else null"},
+		{"3ex", "NUMBER 3 3.0|IDENTIFIER ex null"},
+		{"1.foo", "NUMBER 1 1.0|DOT . null|IDENTIFIER foo null"},
+	} {
+		tokens, errs := tokenize(tc.source)
+		if len(errs) > 0 {
+			t.Errorf("%s: unexpected errors %v", tc.source, errs)
+		}
+		if got := strings.Join(tokens, "|"); got != tc.want {
+			t.Errorf("%s: tokens = %s, want %s", tc.source, got, tc.want)
+		}
+	}
+}
+
+func TestMalformedNumberLiterals(t *testing.T) {
+	for _, tc := range []struct {
+		source string
+		want   string
+	}{
+		{"0x", "Invalid hexadecimal number."},
+		{"0xFFg", "Invalid hexadecimal number."},
+		{"0b", "Invalid binary number."},
+		{"0b102", "Invalid binary number."},
+		{"1e", "Expect digits in number exponent."},
+		{"1e+;", "Expect digits in number exponent."},
+		{"1__0", "Invalid number."},
+		{"1_", "Invalid number."},
+		{"1.2_", "Invalid number."},
+	} {
+		tokens, errs := tokenize(tc.source)
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), tc.want) {
+			t.Errorf("%s: errors = %v, want %q", tc.source, errs, tc.want)
+		}
+		for _, token := range tokens {
+			if strings.HasPrefix(token, "NUMBER") {
+				t.Errorf("%s: malformed literal produced %s", tc.source, token)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import "fmt"
 type LexError struct {
 	line    int
 	message string
+	span    Span
 }
 
 func (le *LexError) String() string {
@@ -14,3 +15,7 @@ func (le *LexError) String() string {
 func (le LexError) Error() string {
 	return fmt.Sprintf("[line %d] Error: %s", le.line, le.message)
 }
+
+func (le LexError) Span() Span {
+	return le.span
+}
@@ -11,6 +11,23 @@ type Token struct {
 	Lexeme  string
 	Literal any
 	Line    int
+
+	// source location of lexeme, columns are 1-based byte columns and End is exclusive
+	Column    int
+	EndLine   int
+	EndColumn int
+	Start     int
+	End       int
+}
+
+// Span is a range of source text, used by errors and tools to point at exact location of token
+type Span struct {
+	Start     int
+	End       int
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
 }
 
 func (t *Token) String() string {
@@ -20,6 +37,17 @@ func (t *Token) String() string {
 	return s
 }
 
+func (t *Token) Span() Span {
+	return Span{
+		Start:     t.Start,
+		End:       t.End,
+		Line:      t.Line,
+		Column:    t.Column,
+		EndLine:   t.EndLine,
+		EndColumn: t.EndColumn,
+	}
+}
+
 // helper function to stringify token's Literal value by converting to string based on type
 func PrintLiteral(literal any) string {
 	switch l := literal.(type) {
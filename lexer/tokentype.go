@@ -12,6 +12,8 @@ const (
 	RIGHT_PAREN
 	LEFT_BRACE
 	RIGHT_BRACE
+	LEFT_BRACKET
+	RIGHT_BRACKET
 	COMMA
 	DOT
 	MINUS
@@ -19,6 +21,8 @@ const (
 	SEMICOLON
 	SLASH
 	STAR
+	QUESTION
+	COLON
 
 	// One or two character tokens
 	BANG
@@ -29,27 +33,37 @@ const (
 	GREATER_EQUAL
 	LESS
 	LESS_EQUAL
+	ARROW
 
 	// Literals
 	STRING
+	INTERPOLATION
 	NUMBER
 	IDENTIFIER
 
 	// Keywords
 	AND
+	BREAK
+	CATCH
 	CLASS
+	CONTINUE
 	ELSE
+	EXPORT
 	FALSE
+	FINALLY
 	FUN
 	FOR
 	IF
+	IMPORT
 	NIL
 	OR
 	PRINT
 	RETURN
 	SUPER
 	THIS
+	THROW
 	TRUE
+	TRY
 	VAR
 	WHILE
 )
@@ -60,6 +74,8 @@ var tokenTypeName = map[TokenType]string{
 	RIGHT_PAREN:   "RIGHT_PAREN",
 	LEFT_BRACE:    "LEFT_BRACE",
 	RIGHT_BRACE:   "RIGHT_BRACE",
+	LEFT_BRACKET:  "LEFT_BRACKET",
+	RIGHT_BRACKET: "RIGHT_BRACKET",
 	COMMA:         "COMMA",
 	DOT:           "DOT",
 	MINUS:         "MINUS",
@@ -67,6 +83,8 @@ var tokenTypeName = map[TokenType]string{
 	SEMICOLON:     "SEMICOLON",
 	SLASH:         "SLASH",
 	STAR:          "STAR",
+	QUESTION:      "QUESTION",
+	COLON:         "COLON",
 	BANG:          "BANG",
 	BANG_EQUAL:    "BANG_EQUAL",
 	EQUAL:         "EQUAL",
@@ -75,23 +93,33 @@ var tokenTypeName = map[TokenType]string{
 	GREATER_EQUAL: "GREATER_EQUAL",
 	LESS:          "LESS",
 	LESS_EQUAL:    "LESS_EQUAL",
+	ARROW:         "ARROW",
 	STRING:        "STRING",
+	INTERPOLATION: "INTERPOLATION",
 	NUMBER:        "NUMBER",
 	IDENTIFIER:    "IDENTIFIER",
 	AND:           "AND",
+	BREAK:         "BREAK",
+	CATCH:         "CATCH",
 	CLASS:         "CLASS",
+	CONTINUE:      "CONTINUE",
 	ELSE:          "ELSE",
+	EXPORT:        "EXPORT",
 	FALSE:         "FALSE",
+	FINALLY:       "FINALLY",
 	FUN:           "FUN",
 	FOR:           "FOR",
 	IF:            "IF",
+	IMPORT:        "IMPORT",
 	NIL:           "NIL",
 	OR:            "OR",
 	PRINT:         "PRINT",
 	RETURN:        "RETURN",
 	SUPER:         "SUPER",
 	THIS:          "THIS",
+	THROW:         "THROW",
 	TRUE:          "TRUE",
+	TRY:           "TRY",
 	VAR:           "VAR",
 	WHILE:         "WHILE",
 }
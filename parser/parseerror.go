@@ -18,3 +18,7 @@ func (le *ParseError) String() string {
 func (le *ParseError) Error() string {
 	return fmt.Sprintf("[line %d] Error: %s", le.token.Line, le.message)
 }
+
+func (le *ParseError) Span() lexer.Span {
+	return le.token.Span()
+}
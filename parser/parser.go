@@ -8,9 +8,10 @@ import (
 )
 
 type Parser struct {
-	tokens  []*lexer.Token
-	current int
-	Errors  []*ParseError
+	tokens     []*lexer.Token
+	current    int
+	blockDepth int // number of blocks being parsed, recovery inside them stops at closing brace
+	Errors     []*ParseError
 }
 
 func NewParser(tokens []*lexer.Token) *Parser {
@@ -21,20 +22,23 @@ func NewParser(tokens []*lexer.Token) *Parser {
 }
 
 // main entry point to run glox statements
+// parsing continues after syntax errors, all errors are saved in p.Errors and first one is returned
 func (p *Parser) Parse() ([]ast.Stmt, error) {
 	var statements []ast.Stmt
 
 	for !p.isAtEnd() {
 		stmt, err := p.declaration()
 		if err != nil {
-			// TODO: probably not return here, return error after loop along with result, because synchronize should be used now
-			// or call synchronize() here instead of inside declaration??
-			// p.synchronize() // remove return err, just sync on error
-			return nil, err
+			// error is already saved, skip to next statement boundary and keep parsing
+			p.synchronize()
+			continue
 		}
 		statements = append(statements, stmt)
 	}
 
+	if len(p.Errors) > 0 {
+		return statements, p.Errors[0]
+	}
 	return statements, nil
 }
 
@@ -69,6 +73,18 @@ func (p *Parser) check(t lexer.TokenType) bool {
 	return p.peek().Type == t
 }
 
+func (p *Parser) checkNext(t lexer.TokenType) bool {
+	if p.isAtEnd() || p.current+1 >= len(p.tokens) {
+		return false
+	}
+	return p.tokens[p.current+1].Type == t
+}
+
+// check for identifier used as contextual keyword
+func (p *Parser) checkWord(word string) bool {
+	return p.check(lexer.IDENTIFIER) && p.peek().Lexeme == word
+}
+
 func (p *Parser) match(tokenTypes ...lexer.TokenType) bool {
 	// if slices.ContainsFunc(tokenTypes, p.check) {
 	// 	p.advance()
@@ -97,7 +113,8 @@ func (p *Parser) consume(t lexer.TokenType, m string) (*lexer.Token, error) {
 *
 * Language grammar expression rule functions:
 * expression     → assignment ;
-*	assignment     → ( call "." )? IDENTIFIER "=" assignment | logic_or ;
+*	assignment     → ( call "." | "super" "." )? IDENTIFIER "=" assignment | call "[" expression "]" "=" assignment | ternary ;
+* ternary        → logic_or ( "?" expression ":" ternary )? ;
 * logic_or       → logic_and ( "or" logic_and )* ;
 * logic_and      → equality ( "and" equality )* ;
 * equality       → comparison ( ( "!=" | "==" ) comparison )* ;
@@ -105,9 +122,14 @@ func (p *Parser) consume(t lexer.TokenType, m string) (*lexer.Token, error) {
 * term           → factor ( ( "-" | "+" ) factor )* ;
 * factor         → unary ( ( "/" | "*" ) unary )* ;
 * unary          → ( "!" | "-" ) unary | call ;
-* call           → primary ( "(" arguments? ")" | "." IDENTIFIER )* ;
+* call           → primary ( "(" arguments? ")" | "." IDENTIFIER | "[" expression "]" )* ;
 * arguments      → expression ( "," expression )* ;
-* primary        → "true" | "false" | "nil" | "this" | NUMBER | STRING | IDENTIFIER | "(" expression ")" | "super" "." IDENTIFIER ;
+* primary        → "true" | "false" | "nil" | "this" | NUMBER | STRING | IDENTIFIER | "(" expression ")" | "super" "." IDENTIFIER | interpolation | lambda | list | map ;
+* list           → "[" ( expression ( "," expression )* ","? )? "]" ;
+* map            → "{" ( entry ( "," entry )* ","? )? "}" ;
+* entry          → expression ":" expression ;
+* lambda         → "fun" "(" parameters? ")" block | "(" parameters? ")" "=>" ( block | expression ) ;
+* interpolation  → INTERPOLATION expression ( INTERPOLATION expression )* STRING ;
  */
 
 func (p *Parser) expression() (ast.Expr, error) {
@@ -115,7 +137,7 @@ func (p *Parser) expression() (ast.Expr, error) {
 }
 
 func (p *Parser) assignment() (ast.Expr, error) {
-	expr, err := p.or()
+	expr, err := p.ternary()
 	if err != nil {
 		return nil, err
 	}
@@ -138,11 +160,23 @@ func (p *Parser) assignment() (ast.Expr, error) {
 				Name:   getExpr.Name,
 				Value:  value,
 			}, nil
+		} else if superExpr, ok := expr.(*ast.Super); ok {
+			// assignment through super runs superclass setter
+			return &ast.SuperSet{
+				Keyword: superExpr.Keyword,
+				Name:    superExpr.Method,
+				Value:   value,
+			}, nil
+		} else if indexExpr, ok := expr.(*ast.IndexGet); ok {
+			return &ast.IndexSet{
+				Object:  indexExpr.Object,
+				Bracket: indexExpr.Bracket,
+				Index:   indexExpr.Index,
+				Value:   value,
+			}, nil
 		} else {
 			// log invalid assignment target error, but don't return
-			// TODO: log this error instead of just printing here
-			assignTargetErr := &ParseError{token: equals, message: "Invalid assignment target."}
-			fmt.Println(assignTargetErr)
+			p.logErrorAt(equals, "Invalid assignment target.")
 		}
 
 	}
@@ -150,6 +184,38 @@ func (p *Parser) assignment() (ast.Expr, error) {
 	return expr, nil
 }
 
+func (p *Parser) ternary() (ast.Expr, error) {
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(lexer.QUESTION) {
+		thenBranch, thenErr := p.expression()
+		if thenErr != nil {
+			return nil, thenErr
+		}
+
+		if _, err := p.consume(lexer.COLON, "Expect ':' after then branch of conditional expression."); err != nil {
+			return nil, err
+		}
+
+		// recursion on else branch makes operator right associative
+		elseBranch, elseErr := p.ternary()
+		if elseErr != nil {
+			return nil, elseErr
+		}
+
+		expr = &ast.Ternary{
+			Condition:  expr,
+			ThenBranch: thenBranch,
+			ElseBranch: elseBranch,
+		}
+	}
+
+	return expr, nil
+}
+
 func (p *Parser) or() (ast.Expr, error) {
 	expr, err := p.and()
 	if err != nil {
@@ -309,10 +375,9 @@ func (p *Parser) finishCall(callee ast.Expr) (ast.Expr, error) {
 	if !p.check(lexer.RIGHT_PAREN) {
 		// do-while loop
 		for hasArg := true; hasArg; hasArg = p.match(lexer.COMMA) {
-			// print too many arguments error but don't stop parsing
+			// log too many arguments error but don't stop parsing
 			if len(arguments) >= 255 {
-				tooManyArgsErr := p.logError("Can't have more than 255 arguments.")
-				fmt.Println(tooManyArgsErr)
+				p.logError("Can't have more than 255 arguments.")
 			}
 
 			arg, argErr := p.expression()
@@ -358,6 +423,20 @@ func (p *Parser) call() (ast.Expr, error) {
 				Object: expr,
 				Name:   name,
 			}
+		} else if p.match(lexer.LEFT_BRACKET) {
+			index, indexErr := p.expression()
+			if indexErr != nil {
+				return nil, indexErr
+			}
+			bracket, bracketErr := p.consume(lexer.RIGHT_BRACKET, "Expect ']' after index.")
+			if bracketErr != nil {
+				return nil, bracketErr
+			}
+			expr = &ast.IndexGet{
+				Object:  expr,
+				Bracket: bracket,
+				Index:   index,
+			}
 		} else {
 			break
 		}
@@ -379,6 +458,9 @@ func (p *Parser) primary() (ast.Expr, error) {
 	if p.match(lexer.NUMBER, lexer.STRING) {
 		return &ast.Literal{Value: p.previous().Literal}, nil
 	}
+	if p.match(lexer.INTERPOLATION) {
+		return p.interpolation()
+	}
 	if p.match(lexer.SUPER) {
 		keyword := p.previous()
 		if _, err := p.consume(lexer.DOT, "Expect '.' after 'super'."); err != nil {
@@ -399,6 +481,27 @@ func (p *Parser) primary() (ast.Expr, error) {
 	if p.match(lexer.IDENTIFIER) {
 		return &ast.Variable{Name: p.previous()}, nil
 	}
+	if p.match(lexer.FUN) {
+		keyword := p.previous()
+		if _, err := p.consume(lexer.LEFT_PAREN, "Expect '(' after 'fun'."); err != nil {
+			return nil, err
+		}
+		function, err := p.functionBody("function", nil)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Lambda{Keyword: keyword, Function: function}, nil
+	}
+	if p.match(lexer.LEFT_BRACKET) {
+		return p.list()
+	}
+	// '{' at start of statement is parsed as block by statement(), so here it can only be a map literal
+	if p.match(lexer.LEFT_BRACE) {
+		return p.mapLiteral()
+	}
+	if p.isArrowFunction() {
+		return p.arrowFunction()
+	}
 	if p.match(lexer.LEFT_PAREN) {
 		expr, err := p.expression()
 		if err != nil {
@@ -417,10 +520,176 @@ func (p *Parser) primary() (ast.Expr, error) {
 	return nil, err
 }
 
+// parse string with embedded expressions, INTERPOLATION token is already consumed
+func (p *Parser) interpolation() (ast.Expr, error) {
+	start := p.previous()
+	parts := []ast.Expr{}
+
+	// do-while loop, each INTERPOLATION segment is followed by an expression
+	for hasSegment := true; hasSegment; hasSegment = p.match(lexer.INTERPOLATION) {
+		if segment := p.previous().Literal.(string); segment != "" {
+			parts = append(parts, &ast.Literal{Value: segment})
+		}
+
+		expr, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, expr)
+
+		if p.check(lexer.INTERPOLATION) {
+			continue
+		}
+		if _, err := p.consume(lexer.STRING, "Expect '}' after interpolated expression."); err != nil {
+			return nil, err
+		}
+		if tail := p.previous().Literal.(string); tail != "" {
+			parts = append(parts, &ast.Literal{Value: tail})
+		}
+	}
+
+	return &ast.Interpolation{Start: start, Parts: parts}, nil
+}
+
+// parse list literal elements, '[' is already consumed
+func (p *Parser) list() (ast.Expr, error) {
+	elements := []ast.Expr{}
+
+	for !p.check(lexer.RIGHT_BRACKET) && !p.isAtEnd() {
+		element, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+
+		// trailing comma before ']' is allowed
+		if !p.match(lexer.COMMA) {
+			break
+		}
+	}
+
+	bracket, err := p.consume(lexer.RIGHT_BRACKET, "Expect ']' after list elements.")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.List{
+		Bracket:  bracket,
+		Elements: elements,
+	}, nil
+}
+
+// parse map literal entries, '{' is already consumed
+func (p *Parser) mapLiteral() (ast.Expr, error) {
+	keys := []ast.Expr{}
+	values := []ast.Expr{}
+
+	for !p.check(lexer.RIGHT_BRACE) && !p.isAtEnd() {
+		key, keyErr := p.expression()
+		if keyErr != nil {
+			return nil, keyErr
+		}
+
+		if _, err := p.consume(lexer.COLON, "Expect ':' after map key."); err != nil {
+			return nil, err
+		}
+
+		value, valueErr := p.expression()
+		if valueErr != nil {
+			return nil, valueErr
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+
+		// trailing comma before '}' is allowed
+		if !p.match(lexer.COMMA) {
+			break
+		}
+	}
+
+	brace, err := p.consume(lexer.RIGHT_BRACE, "Expect '}' after map entries.")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.Map{
+		Brace:  brace,
+		Keys:   keys,
+		Values: values,
+	}, nil
+}
+
+// look ahead for "(" parameters? ")" "=>" without consuming tokens, to tell arrow function apart from grouping
+func (p *Parser) isArrowFunction() bool {
+	if !p.check(lexer.LEFT_PAREN) {
+		return false
+	}
+
+	i := p.current + 1
+	if p.tokens[i].Type == lexer.IDENTIFIER {
+		i++
+		for p.tokens[i].Type == lexer.COMMA && p.tokens[i+1].Type == lexer.IDENTIFIER {
+			i += 2
+		}
+	}
+
+	return p.tokens[i].Type == lexer.RIGHT_PAREN && p.tokens[i+1].Type == lexer.ARROW
+}
+
+// parse (a, b) => expression, body of arrow function returns value of its expression.
+// '{' after arrow starts block body like fun has, map literal body needs parentheses
+func (p *Parser) arrowFunction() (ast.Expr, error) {
+	p.advance()
+
+	parameters, paramsErr := p.parameters()
+	if paramsErr != nil {
+		return nil, paramsErr
+	}
+
+	if _, err := p.consume(lexer.RIGHT_PAREN, "Expect ')' after parameters."); err != nil {
+		return nil, err
+	}
+	arrow, arrowErr := p.consume(lexer.ARROW, "Expect '=>' after parameters.")
+	if arrowErr != nil {
+		return nil, arrowErr
+	}
+
+	if p.match(lexer.LEFT_BRACE) {
+		body, bodyErr := p.block()
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+		return &ast.Lambda{
+			Keyword:  arrow,
+			Function: &ast.Function{Params: parameters, Body: body},
+		}, nil
+	}
+
+	value, valueErr := p.expression()
+	if valueErr != nil {
+		return nil, valueErr
+	}
+
+	return &ast.Lambda{
+		Keyword: arrow,
+		Function: &ast.Function{
+			Params: parameters,
+			Body: []ast.Stmt{
+				&ast.Return{Keyword: arrow, Value: value},
+			},
+		},
+	}, nil
+}
+
 // save errors
 func (p *Parser) logError(message string) *ParseError {
+	return p.logErrorAt(p.peek(), message)
+}
+
+func (p *Parser) logErrorAt(token *lexer.Token, message string) *ParseError {
 	err := &ParseError{
-		token:   p.peek(),
+		token:   token,
 		message: message,
 	}
 	p.Errors = append(p.Errors, err)
@@ -429,12 +698,19 @@ func (p *Parser) logError(message string) *ParseError {
 
 // synchronize on parsing errors
 func (p *Parser) synchronize() {
+	// closing brace ends block which had error, leave it for block to consume
+	if p.blockDepth > 0 && p.check(lexer.RIGHT_BRACE) {
+		return
+	}
 	p.advance()
 
 	for !p.isAtEnd() {
 		if p.previous().Type == lexer.SEMICOLON {
 			return
 		}
+		if p.blockDepth > 0 && p.check(lexer.RIGHT_BRACE) {
+			return
+		}
 
 		switch p.peek().Type {
 		case lexer.CLASS:
@@ -452,10 +728,26 @@ func (p *Parser) synchronize() {
 		case lexer.PRINT:
 			fallthrough
 		case lexer.RETURN:
+			fallthrough
+		case lexer.BREAK:
+			fallthrough
+		case lexer.CONTINUE:
+			fallthrough
+		case lexer.THROW:
+			fallthrough
+		case lexer.TRY:
+			fallthrough
+		case lexer.IMPORT:
+			fallthrough
+		case lexer.EXPORT:
+			return
+		}
+
+		// contextual keywords start declaration only in same position declaration() accepts them
+		if (p.checkWord("trait") && p.checkNext(lexer.IDENTIFIER)) || (p.checkWord("from") && p.checkNext(lexer.STRING)) {
 			return
-		default:
-			p.advance()
 		}
+		p.advance()
 	}
 }
 
@@ -464,13 +756,18 @@ func (p *Parser) synchronize() {
 *
 * Language grammar statements rule functions:
 *	program        → declaration* EOF ;
-*	declaration    → classDecl | funDecl | varDecl | statement ;
-* classDecl      → "class" IDENTIFIER ( "<" IDENTIFIER )? "{" function* "}" ;
+*	declaration    → exportDecl | importDecl | classDecl | traitDecl | funDecl | varDecl | statement ;
+* exportDecl     → "export" ( classDecl | traitDecl | funDecl | varDecl ) ;
+* importDecl     → "import" STRING "as" IDENTIFIER ";" | "from" STRING "import" IDENTIFIER ( "," IDENTIFIER )* ";" ;
+* classDecl      → "class" IDENTIFIER ( "<" IDENTIFIER )? ( "with" IDENTIFIER ( "," IDENTIFIER )* )? "{" ( "class"? function | getter | setter )* "}" ;
+* getter         → IDENTIFIER block ;
+* setter         → "set" function ;
+* traitDecl      → "trait" IDENTIFIER "{" function* "}" ;
 *	funDecl        → "fun" function ;
 *	function       → IDENTIFIER "(" parameters? ")" block ;
 *	parameters     → IDENTIFIER ( "," IDENTIFIER )* ;
 *	varDecl        → "var" IDENTIFIER ( "=" expression )? ";" ;
-*	statement      → exprStmt | forStmt | ifStm | printStmt | returnStmt | whileStmt | block;
+*	statement      → exprStmt | forStmt | ifStm | printStmt | returnStmt | whileStmt | breakStmt | continueStmt | throwStmt | tryStmt | block;
 * forStmt        → "for" "(" ( varDecl | exprStmt | ";" ) expression? ";" expression? ")" statement ;
 * whileStmt      → "while" "(" expression ")" statement ;
 * ifStmt         → "if" "(" expression ")" statement ( "else" statement )? ;
@@ -478,14 +775,40 @@ func (p *Parser) synchronize() {
 *	exprStmt       → expression ";" ;
 *	printStmt      → "print" expression ";" ;
 * returnStmt     → "return" expression? ";" ;
+* breakStmt      → "break" ";" ;
+* continueStmt   → "continue" ";" ;
+* throwStmt      → "throw" expression ";" ;
+* tryStmt        → "try" block ( "catch" "(" IDENTIFIER ")" block )? ( "finally" block )? ;
  */
 
 func (p *Parser) declaration() (ast.Stmt, error) {
+	if p.match(lexer.EXPORT) {
+		return p.exportDeclaration()
+	}
+
+	if p.match(lexer.IMPORT) {
+		return p.importDeclaration()
+	}
+
+	// 'from' is not reserved, it only starts import when followed by module path
+	if p.checkWord("from") && p.checkNext(lexer.STRING) {
+		p.advance()
+		return p.fromImportDeclaration()
+	}
+
 	if p.match(lexer.CLASS) {
 		return p.classDeclaration()
 	}
 
-	if p.match(lexer.FUN) {
+	// 'trait' is not reserved, it only starts declaration when followed by trait name
+	if p.checkWord("trait") && p.checkNext(lexer.IDENTIFIER) {
+		p.advance()
+		return p.traitDeclaration()
+	}
+
+	// 'fun' followed by '(' is anonymous function expression, leave it for expression statement
+	if p.check(lexer.FUN) && !p.checkNext(lexer.LEFT_PAREN) {
+		p.advance()
 		return p.function("function")
 	}
 
@@ -496,6 +819,93 @@ func (p *Parser) declaration() (ast.Stmt, error) {
 	return p.statement()
 }
 
+func (p *Parser) exportDeclaration() (ast.Stmt, error) {
+	keyword := p.previous()
+
+	var declaration ast.Stmt
+	var err error
+	if p.match(lexer.CLASS) {
+		declaration, err = p.classDeclaration()
+	} else if p.checkWord("trait") && p.checkNext(lexer.IDENTIFIER) {
+		p.advance()
+		declaration, err = p.traitDeclaration()
+	} else if p.check(lexer.FUN) && p.checkNext(lexer.IDENTIFIER) {
+		p.advance()
+		declaration, err = p.function("function")
+	} else if p.match(lexer.VAR) {
+		declaration, err = p.varDeclaration()
+	} else {
+		return nil, p.logError("Expect class, trait, function or variable declaration after 'export'.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.Export{
+		Keyword:     keyword,
+		Declaration: declaration,
+	}, nil
+}
+
+func (p *Parser) importDeclaration() (ast.Stmt, error) {
+	keyword := p.previous()
+
+	path, pathErr := p.consume(lexer.STRING, "Expect module path after 'import'.")
+	if pathErr != nil {
+		return nil, pathErr
+	}
+
+	if !p.checkWord("as") {
+		return nil, p.logError("Expect 'as' after module path.")
+	}
+	p.advance()
+
+	alias, aliasErr := p.consume(lexer.IDENTIFIER, "Expect module name after 'as'.")
+	if aliasErr != nil {
+		return nil, aliasErr
+	}
+
+	if _, err := p.consume(lexer.SEMICOLON, "Expect ';' after import."); err != nil {
+		return nil, err
+	}
+
+	return &ast.Import{
+		Keyword: keyword,
+		Path:    path,
+		Alias:   alias,
+	}, nil
+}
+
+// parse rest of from "path" import a, b; after 'from' is consumed
+func (p *Parser) fromImportDeclaration() (ast.Stmt, error) {
+	path := p.advance()
+
+	keyword, keywordErr := p.consume(lexer.IMPORT, "Expect 'import' after module path.")
+	if keywordErr != nil {
+		return nil, keywordErr
+	}
+
+	names := []*lexer.Token{}
+	// do-while loop
+	for hasName := true; hasName; hasName = p.match(lexer.COMMA) {
+		name, nameErr := p.consume(lexer.IDENTIFIER, "Expect imported name.")
+		if nameErr != nil {
+			return nil, nameErr
+		}
+		names = append(names, name)
+	}
+
+	if _, err := p.consume(lexer.SEMICOLON, "Expect ';' after import."); err != nil {
+		return nil, err
+	}
+
+	return &ast.Import{
+		Keyword: keyword,
+		Path:    path,
+		Names:   names,
+	}, nil
+}
+
 func (p *Parser) statement() (ast.Stmt, error) {
 	if p.match(lexer.FOR) {
 		return p.forStatement()
@@ -517,6 +927,22 @@ func (p *Parser) statement() (ast.Stmt, error) {
 		return p.whileStatement()
 	}
 
+	if p.match(lexer.BREAK) {
+		return p.breakStatement()
+	}
+
+	if p.match(lexer.CONTINUE) {
+		return p.continueStatement()
+	}
+
+	if p.match(lexer.THROW) {
+		return p.throwStatement()
+	}
+
+	if p.match(lexer.TRY) {
+		return p.tryStatement()
+	}
+
 	if p.match(lexer.LEFT_BRACE) {
 		statements, err := p.block()
 		if err != nil {
@@ -542,17 +968,70 @@ func (p *Parser) classDeclaration() (ast.Stmt, error) {
 		superclass = &ast.Variable{Name: p.previous()}
 	}
 
+	// 'with' is not reserved, it can only follow class name or superclass here
+	traits := []*ast.Variable{}
+	if p.checkWord("with") {
+		p.advance()
+		for {
+			if _, err := p.consume(lexer.IDENTIFIER, "Expect trait name."); err != nil {
+				return nil, err
+			}
+			traits = append(traits, &ast.Variable{Name: p.previous()})
+			if !p.match(lexer.COMMA) {
+				break
+			}
+		}
+	}
+
 	if _, err := p.consume(lexer.LEFT_BRACE, "Expect '{' before class body."); err != nil {
 		return nil, err
 	}
 
 	methods := []*ast.Function{}
+	classMethods := []*ast.Function{}
+	getters := []*ast.Function{}
+	setters := []*ast.Function{}
 	for !p.check(lexer.RIGHT_BRACE) && !p.isAtEnd() {
+		// 'class' modifier declares method on class object itself
+		isClassMethod := p.match(lexer.CLASS)
+
+		// method name followed by block without parameter list is a getter
+		if p.check(lexer.IDENTIFIER) && p.checkNext(lexer.LEFT_BRACE) {
+			if isClassMethod {
+				return nil, p.logError("Class methods can't be getters.")
+			}
+			getter, err := p.getter()
+			if err != nil {
+				return nil, err
+			}
+			getters = append(getters, getter)
+			continue
+		}
+
+		// 'set' is not reserved, it only marks setter when followed by property name
+		if p.checkWord("set") && p.checkNext(lexer.IDENTIFIER) {
+			if isClassMethod {
+				return nil, p.logError("Class methods can't be setters.")
+			}
+			p.advance()
+			setter, err := p.function("setter")
+			if err != nil {
+				return nil, err
+			}
+			setters = append(setters, setter)
+			continue
+		}
+
 		function, err := p.function("method")
 		if err != nil {
 			return nil, err
 		}
-		methods = append(methods, function)
+
+		if isClassMethod {
+			classMethods = append(classMethods, function)
+		} else {
+			methods = append(methods, function)
+		}
 	}
 
 	if _, err := p.consume(lexer.RIGHT_BRACE, "Expect '}' after class body."); err != nil {
@@ -560,13 +1039,65 @@ func (p *Parser) classDeclaration() (ast.Stmt, error) {
 	}
 
 	return &ast.Class{
-		Name:       name,
-		Superclass: superclass,
-		Methods:    methods,
+		Name:         name,
+		Superclass:   superclass,
+		Methods:      methods,
+		ClassMethods: classMethods,
+		Getters:      getters,
+		Setters:      setters,
+		Traits:       traits,
+	}, nil
+}
+
+func (p *Parser) traitDeclaration() (ast.Stmt, error) {
+	name, nameErr := p.consume(lexer.IDENTIFIER, "Expect trait name.")
+	if nameErr != nil {
+		return nil, nameErr
+	}
+
+	if _, err := p.consume(lexer.LEFT_BRACE, "Expect '{' before trait body."); err != nil {
+		return nil, err
+	}
+
+	methods := []*ast.Function{}
+	for !p.check(lexer.RIGHT_BRACE) && !p.isAtEnd() {
+		function, err := p.function("method")
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, function)
+	}
+
+	if _, err := p.consume(lexer.RIGHT_BRACE, "Expect '}' after trait body."); err != nil {
+		return nil, err
+	}
+
+	return &ast.Trait{
+		Name:    name,
+		Methods: methods,
+	}, nil
+}
+
+// parse getter body, getters have no parameter list so Params is left empty
+func (p *Parser) getter() (*ast.Function, error) {
+	name := p.advance()
+	p.advance()
+
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.Function{
+		Name:   name,
+		Params: []*lexer.Token{},
+		Body:   body,
 	}, nil
 }
 
 func (p *Parser) forStatement() (ast.Stmt, error) {
+	keyword := p.previous()
+
 	_, lParenErr := p.consume(lexer.LEFT_PAREN, "Expect '(' after 'for'.")
 	if lParenErr != nil {
 		return nil, lParenErr
@@ -619,23 +1150,15 @@ func (p *Parser) forStatement() (ast.Stmt, error) {
 		return nil, bodyErr
 	}
 
-	if increment != nil {
-		body = &ast.Block{
-			Statements: []ast.Stmt{
-				body,
-				&ast.Expression{
-					Expression: increment,
-				},
-			},
-		}
-	}
-
 	if condition == nil {
 		condition = &ast.Literal{Value: true}
 	}
+	// increment is kept on loop node instead of appending to body, so that 'continue' does not skip it
 	body = &ast.While{
+		Keyword:   keyword,
 		Condition: condition,
 		Body:      body,
+		Increment: increment,
 	}
 
 	if initializer != nil {
@@ -711,6 +1234,8 @@ func (p *Parser) varDeclaration() (ast.Stmt, error) {
 }
 
 func (p *Parser) whileStatement() (ast.Stmt, error) {
+	keyword := p.previous()
+
 	_, lParenErr := p.consume(lexer.LEFT_PAREN, "Expect '(' after 'while'.")
 	if lParenErr != nil {
 		return nil, lParenErr
@@ -732,12 +1257,14 @@ func (p *Parser) whileStatement() (ast.Stmt, error) {
 	}
 
 	return &ast.While{
+		Keyword:   keyword,
 		Condition: cond,
 		Body:      body,
 	}, nil
 }
 
 func (p *Parser) printStatement() (ast.Stmt, error) {
+	keyword := p.previous()
 	value, err := p.expression()
 	if err != nil {
 		return nil, err
@@ -748,7 +1275,7 @@ func (p *Parser) printStatement() (ast.Stmt, error) {
 		return nil, semiErr
 	}
 
-	return &ast.Print{Expression: value}, nil
+	return &ast.Print{Keyword: keyword, Expression: value}, nil
 }
 
 func (p *Parser) returnStatement() (ast.Stmt, error) {
@@ -773,6 +1300,98 @@ func (p *Parser) returnStatement() (ast.Stmt, error) {
 	}, nil
 }
 
+func (p *Parser) breakStatement() (ast.Stmt, error) {
+	keyword := p.previous()
+
+	if _, err := p.consume(lexer.SEMICOLON, "Expect ';' after 'break'."); err != nil {
+		return nil, err
+	}
+
+	return &ast.Break{Keyword: keyword}, nil
+}
+
+func (p *Parser) continueStatement() (ast.Stmt, error) {
+	keyword := p.previous()
+
+	if _, err := p.consume(lexer.SEMICOLON, "Expect ';' after 'continue'."); err != nil {
+		return nil, err
+	}
+
+	return &ast.Continue{Keyword: keyword}, nil
+}
+
+func (p *Parser) throwStatement() (ast.Stmt, error) {
+	keyword := p.previous()
+
+	value, valErr := p.expression()
+	if valErr != nil {
+		return nil, valErr
+	}
+
+	if _, err := p.consume(lexer.SEMICOLON, "Expect ';' after thrown value."); err != nil {
+		return nil, err
+	}
+
+	return &ast.Throw{
+		Keyword: keyword,
+		Value:   value,
+	}, nil
+}
+
+func (p *Parser) tryStatement() (ast.Stmt, error) {
+	if _, err := p.consume(lexer.LEFT_BRACE, "Expect '{' after 'try'."); err != nil {
+		return nil, err
+	}
+	body, bodyErr := p.block()
+	if bodyErr != nil {
+		return nil, bodyErr
+	}
+
+	var catchName *lexer.Token
+	var catchBody []ast.Stmt
+	hasCatch := p.match(lexer.CATCH)
+	if hasCatch {
+		if _, err := p.consume(lexer.LEFT_PAREN, "Expect '(' after 'catch'."); err != nil {
+			return nil, err
+		}
+		name, nameErr := p.consume(lexer.IDENTIFIER, "Expect error variable name.")
+		if nameErr != nil {
+			return nil, nameErr
+		}
+		catchName = name
+		if _, err := p.consume(lexer.RIGHT_PAREN, "Expect ')' after error variable name."); err != nil {
+			return nil, err
+		}
+		if _, err := p.consume(lexer.LEFT_BRACE, "Expect '{' before catch body."); err != nil {
+			return nil, err
+		}
+		catchBody, bodyErr = p.block()
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+	}
+
+	var finallyBody []ast.Stmt
+	if p.match(lexer.FINALLY) {
+		if _, err := p.consume(lexer.LEFT_BRACE, "Expect '{' after 'finally'."); err != nil {
+			return nil, err
+		}
+		finallyBody, bodyErr = p.block()
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+	} else if !hasCatch {
+		return nil, p.logError("Expect 'catch' or 'finally' after try block.")
+	}
+
+	return &ast.Try{
+		Body:        body,
+		CatchName:   catchName,
+		CatchBody:   catchBody,
+		FinallyBody: finallyBody,
+	}, nil
+}
+
 func (p *Parser) expressionStatement() (ast.Stmt, error) {
 	expr, err := p.expression()
 	if err != nil {
@@ -790,10 +1409,15 @@ func (p *Parser) expressionStatement() (ast.Stmt, error) {
 func (p *Parser) block() ([]ast.Stmt, error) {
 	statements := []ast.Stmt{}
 
+	p.blockDepth++
+	defer func() { p.blockDepth-- }()
+
 	for !p.check(lexer.RIGHT_BRACE) && !p.isAtEnd() {
 		stmt, err := p.declaration()
 		if err != nil {
-			return nil, err
+			// error is already saved, recover at next statement inside block
+			p.synchronize()
+			continue
 		}
 		statements = append(statements, stmt)
 	}
@@ -816,23 +1440,14 @@ func (p *Parser) function(kind string) (*ast.Function, error) {
 		return nil, err
 	}
 
-	parameters := []*lexer.Token{}
-
-	if !p.check(lexer.RIGHT_PAREN) {
-		// do-while loop
-		for hasArg := true; hasArg; hasArg = p.match(lexer.COMMA) {
-			// print too many arguments error but don't stop parsing
-			if len(parameters) >= 255 {
-				tooManyArgsErr := p.logError("Can't have more than 255 parameters.")
-				fmt.Println(tooManyArgsErr)
-			}
+	return p.functionBody(kind, name)
+}
 
-			arg, argErr := p.consume(lexer.IDENTIFIER, "Expect parameter name.")
-			if argErr != nil {
-				return nil, argErr
-			}
-			parameters = append(parameters, arg)
-		}
+// parse parameters and body of function after its '(', name is nil for anonymous functions
+func (p *Parser) functionBody(kind string, name *lexer.Token) (*ast.Function, error) {
+	parameters, paramsErr := p.parameters()
+	if paramsErr != nil {
+		return nil, paramsErr
 	}
 
 	if _, err := p.consume(lexer.RIGHT_PAREN, "Expect ')' after parameters."); err != nil {
@@ -854,3 +1469,25 @@ func (p *Parser) function(kind string) (*ast.Function, error) {
 		Body:   body,
 	}, nil
 }
+
+func (p *Parser) parameters() ([]*lexer.Token, error) {
+	parameters := []*lexer.Token{}
+
+	if !p.check(lexer.RIGHT_PAREN) {
+		// do-while loop
+		for hasArg := true; hasArg; hasArg = p.match(lexer.COMMA) {
+			// log too many parameters error but don't stop parsing
+			if len(parameters) >= 255 {
+				p.logError("Can't have more than 255 parameters.")
+			}
+
+			arg, argErr := p.consume(lexer.IDENTIFIER, "Expect parameter name.")
+			if argErr != nil {
+				return nil, argErr
+			}
+			parameters = append(parameters, arg)
+		}
+	}
+
+	return parameters, nil
+}
@@ -115,10 +115,18 @@ func main() {
 		"Call     : Expr callee, *lexer.Token paren, []Expr arguments",
 		"Get      : Expr object, *lexer.Token name",
 		"Grouping : Expr expression",
+		"IndexGet : Expr object, *lexer.Token bracket, Expr index",
+		"IndexSet : Expr object, *lexer.Token bracket, Expr index, Expr value",
+		"Interpolation : *lexer.Token start, []Expr parts",
+		"Lambda   : *lexer.Token keyword, *Function function",
+		"List     : *lexer.Token bracket, []Expr elements",
 		"Literal  : any value",
 		"Logical  : Expr left, *lexer.Token operator, Expr right",
+		"Map      : *lexer.Token brace, []Expr keys, []Expr values",
 		"Set      : Expr object, *lexer.Token name, Expr value",
 		"Super    : *lexer.Token keyword, *lexer.Token method",
+		"SuperSet : *lexer.Token keyword, *lexer.Token name, Expr value",
+		"Ternary  : Expr condition, Expr thenBranch, Expr elseBranch",
 		"This     : *lexer.Token keyword",
 		"Unary    : *lexer.Token operator, Expr right",
 		"Variable : *lexer.Token name",
@@ -126,13 +134,20 @@ func main() {
 
 	defineAst("ast", "Stmt", []string{
 		"Block			: []Stmt statements",
-		"Class      : *lexer.Token name, *Variable superclass, []*Function methods",
+		"Break      : *lexer.Token keyword",
+		"Class      : *lexer.Token name, *Variable superclass, []*Function methods, []*Function classMethods, []*Function getters, []*Function setters, []*Variable traits",
+		"Continue   : *lexer.Token keyword",
+		"Export     : *lexer.Token keyword, Stmt declaration",
 		"Expression	: Expr expression",
 		"Function   : *lexer.Token name, []*lexer.Token params, []Stmt body",
 		"If         : Expr condition, Stmt thenBranch, Stmt elseBranch",
-		"Print      : Expr expression",
+		"Import     : *lexer.Token keyword, *lexer.Token path, *lexer.Token alias, []*lexer.Token names",
+		"Print      : *lexer.Token keyword, Expr expression",
 		"Return     : *lexer.Token keyword, Expr value",
+		"Throw      : *lexer.Token keyword, Expr value",
+		"Trait      : *lexer.Token name, []*Function methods",
+		"Try        : []Stmt body, *lexer.Token catchName, []Stmt catchBody, []Stmt finallyBody",
 		"Var        : *lexer.Token name, Expr initializer",
-		"While      : Expr condition, Stmt body",
+		"While      : *lexer.Token keyword, Expr condition, Stmt body, Expr increment",
 	})
 }